@@ -1,18 +1,120 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	_ "time/tzdata" // Zone names in typed times work without system zoneinfo
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/craigderington/skyterm/internal/app"
+	"github.com/craigderington/skyterm/internal/config"
 )
 
+// flags holds the command line; each set flag overrides config.yaml
+type flags struct {
+	config    string
+	lat, lon  float64
+	elevation float64
+	site      string
+	place     string
+	time      string
+	paused    bool
+	target    string
+	fov       float64
+	theme     string
+
+	set map[string]bool // Names of the flags given
+}
+
+func parseFlags() *flags {
+	f := &flags{set: map[string]bool{}}
+	flag.StringVar(&f.config, "config", "", "read this config file instead of ~/.config/skyterm/config.yaml")
+	flag.Float64Var(&f.lat, "lat", 0, "observer latitude in degrees, positive north")
+	flag.Float64Var(&f.lon, "lon", 0, "observer longitude in degrees, positive east")
+	flag.Float64Var(&f.elevation, "elev", 0, "observer elevation in meters")
+	flag.StringVar(&f.site, "site", "", "observe from a configured site, or a gazetteer city")
+	flag.StringVar(&f.place, "place", "", `observe from a city, e.g. "Flagstaff" or "Portland, ME"`)
+	flag.StringVar(&f.time, "time", "", `start time, e.g. "2026-08-12 22:00", "+2h", "sunset"`)
+	flag.BoolVar(&f.paused, "paused", false, "start with the clock stopped")
+	flag.StringVar(&f.target, "target", "", `center on an object or coordinates, e.g. "M31"`)
+	flag.Float64Var(&f.fov, "fov", 0, "field of view in degrees")
+	flag.StringVar(&f.theme, "theme", "", "color theme: default, high-contrast, monochrome, night")
+	flag.Parse()
+
+	flag.Visit(func(fl *flag.Flag) { f.set[fl.Name] = true })
+	return f
+}
+
+// loadConfig reads the config file named by --config, or the default one
+func (f *flags) loadConfig() (*config.Config, error) {
+	var cfg *config.Config
+	var err error
+	if f.config != "" {
+		cfg, err = config.LoadFile(f.config)
+	} else {
+		cfg, err = config.Load()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	return cfg, nil
+}
+
+// siteFlags collects the flags that choose the site
+func (f *flags) siteFlags() app.SiteFlags {
+	var sf app.SiteFlags
+	if f.set["lat"] {
+		sf.Lat = &f.lat
+	}
+	if f.set["lon"] {
+		sf.Lon = &f.lon
+	}
+	if f.set["elev"] {
+		sf.Elevation = &f.elevation
+	}
+	if f.set["site"] {
+		sf.Site = f.site
+	}
+	if f.set["place"] {
+		sf.Place = f.place
+	}
+	return sf
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(configCommand(os.Args[2:]))
+	}
+
+	f := parseFlags()
+
+	cfg, err := f.loadConfig()
+	if err != nil {
+		fail(err)
+	}
+
+	site, err := app.StartSite(cfg, f.siteFlags())
+	if err != nil {
+		fail(err)
+	}
+
+	model, err := app.New(cfg, app.Options{
+		Site:   site,
+		Time:   f.time,
+		Paused: f.paused,
+		Target: f.target,
+		FOV:    f.fov,
+		Theme:  f.theme,
+	})
+	if err != nil {
+		fail(err)
+	}
+
 	p := tea.NewProgram(
-		app.New(),
+		model,
 		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
+		tea.WithMouseAllMotion(), // Motion without a button held drives hover tooltips
 	)
 
 	if _, err := p.Run(); err != nil {
@@ -20,3 +122,43 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// configCommand runs "skyterm config check [--config path]", printing
+// each problem with the config file. It returns the exit status.
+func configCommand(args []string) int {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	path := fs.String("config", config.Path(), "config file to check")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: skyterm config check [--config path]")
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "check" {
+		fs.Usage()
+		return 2
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	cfg, err := config.LoadFile(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skyterm: %v\n", err)
+		return 1
+	}
+
+	warnings := app.ConfigWarnings(cfg)
+	for _, w := range warnings {
+		fmt.Printf("%s: %s\n", *path, w)
+	}
+	if len(warnings) > 0 {
+		return 1
+	}
+	fmt.Printf("%s: OK\n", *path)
+	return 0
+}
+
+// fail reports a startup error and exits
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "skyterm: %v\n", err)
+	os.Exit(2)
+}
@@ -3,7 +3,10 @@ package app
 import (
 	"fmt"
 	"math"
+	"os"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,7 +14,9 @@ import (
 	"github.com/craigderington/skyterm/internal/astro"
 	"github.com/craigderington/skyterm/internal/catalog"
 	"github.com/craigderington/skyterm/internal/config"
+	"github.com/craigderington/skyterm/internal/optics"
 	"github.com/craigderington/skyterm/internal/render"
+	"github.com/craigderington/skyterm/internal/theme"
 	"github.com/craigderington/skyterm/internal/ui"
 )
 
@@ -33,19 +38,61 @@ type Model struct {
 	showPlanetLabels   bool
 	showDeepSky        bool
 	showStarLabels     bool
+	showDaylight       bool // Sky brightness from Sun and Moon hides faint objects
+	showMinimap        bool // All-sky inset with the current view outlined
 	magnitudeLimit     float64
 	showHelp           bool
 	showInfo           bool
 
+	// Equipment overlay
+	setups        []optics.Setup // Every configured equipment combination
+	setupIndex    int            // Active setup, -1 when the overlay is off
+	frameRotation float64        // Degrees added to a camera frame's rotation
+
+	// Motion trails
+	trailMode trailMode
+	trails    []astro.Trail
+	trailSpan time.Duration // Sampled on each side of the time the trail was built
+	trailStep time.Duration
+
+	// Long-exposure star trails
+	showStarTrails bool
+	starTrails     *render.StarTrails
+	exposureTime   time.Time // Simulated time accumulated up to
+
+	// Transient message shown in the status bar until the next key press
+	statusMessage string
+
 	// Interaction state
 	selectedObject *SelectedObject
 	objectInfo     *ui.ObjectInfo // Info for selected object, including image
 	following      bool
 	searchMode     bool
 	searchQuery    string
+	searchResults  []ui.SearchResult // Ranked matches for searchQuery
+	searchIndex    int               // Chosen result
 	timeInputMode  bool
 	timeInput      string
-	imageViewMode  bool // True when viewing fullscreen image
+	timeError      string // Why the last typed time was rejected
+	cmdMode        bool
+	cmdInput       string
+	cmdCandidates  []string // Completions offered by the last Tab
+	cmdHistory     []string
+	cmdHistoryPos  int // Index into cmdHistory while browsing, len when not
+	gotoMode       bool
+	gotoInput      string
+	gotoError      string          // Why the last go-to entry was rejected
+	marker         *catalog.Marker // Go-to target, nil when none
+	siteMode       bool
+	siteInput      string
+	siteChoices    []siteChoice // Sites matching siteInput
+	siteIndex      int          // Chosen site
+	siteError      string
+	imageViewMode  bool   // True when viewing fullscreen image
+	helpScroll     int    // First help line shown
+	helpQuery      string // Filters the help screen
+	helpSearching  bool   // helpQuery is being typed
+	mouse          mouseState
 
 	// Time and location
 	currentTime    time.Time
@@ -53,20 +100,39 @@ type Model struct {
 	paused         bool
 	timeStep       time.Duration
 	timeMultiplier float64
-	realTimeBase   time.Time // When we started or last resumed real-time mode
+	realTimeBase   time.Time        // Real time of the last tick or resume
+	now            func() time.Time // Reads the real clock; fixed in tests
+	zoneMode       zoneMode
 
 	// Data
 	starCatalog     *catalog.StarCatalog
 	deepSkyCatalog  *catalog.DeepSkyCatalog
 	planetarySystem *astro.PlanetarySystem
+	sky             astro.SkyConditions
 	canvas          *render.Canvas
 
 	// Config
 	config *config.Config
 }
 
-func New() Model {
-	cfg, _ := config.Load()
+// Options override the config and startup script, as given on the command
+// line
+type Options struct {
+	Site   *config.SiteConfig // Observe from here instead of the configured site
+	Time   string             // Start time, in any form the time prompt accepts
+	Paused bool               // Start with the clock stopped
+	Target string             // Object or coordinates to center on, as for :goto
+	FOV    float64            // Field of view in degrees; 0 keeps the default
+	Theme  string             // Color theme name; empty keeps the config's
+}
+
+// New builds the model from the config and startup script, then applies
+// the options. Only a bad option is an error.
+func New(cfg *config.Config, opts Options) (Model, error) {
+	site := cfg.StartSite()
+	if opts.Site != nil {
+		site = *opts.Site
+	}
 
 	// Parse time step from config
 	timeStep, err := time.ParseDuration(cfg.Time.TimeStep)
@@ -74,10 +140,28 @@ func New() Model {
 		timeStep = 1 * time.Minute // Default to 1 minute
 	}
 
+	// Trail sampling; fall back to ±30 days in daily steps
+	trailSpan, err := config.ParseDuration(cfg.Display.TrailSpan)
+	if err != nil {
+		trailSpan = 30 * 24 * time.Hour
+	}
+	trailStep, err := config.ParseDuration(cfg.Display.TrailStep)
+	if err != nil || trailStep <= 0 {
+		trailStep = 24 * time.Hour
+	}
+
+	// Apply color theme; unknown names fall back to the default theme
+	th, _ := theme.ByName(cfg.Display.Theme)
+	theme.Set(th)
+
+	// A bad keys: section falls back to the defaults, reported with the
+	// config warnings once running
+	keys, _ := loadKeyMap(cfg.Keys)
+
 	now := time.Now()
 
-	return Model{
-		keys:               newKeyMap(),
+	m := Model{
+		keys:               keys,
 		altitude:           45.0,  // Start looking 45° up
 		azimuth:            180.0, // South
 		fov:                60.0,  // 60° field of view
@@ -88,51 +172,120 @@ func New() Model {
 		showPlanetLabels:   cfg.Display.ShowPlanetLabels,
 		showDeepSky:        false,
 		showStarLabels:     true, // Show star labels by default
+		showDaylight:       cfg.Display.SimulateDaylight,
 		magnitudeLimit:     cfg.Display.MagnitudeLimit,
+		setups:             cfg.Equipment.Setups(),
+		setupIndex:         -1,
+		trailSpan:          trailSpan,
+		trailStep:          trailStep,
+		starTrails:         render.NewStarTrails(),
 		currentTime:        now,
-		observer:           cfg.Observer(),
+		observer:           site.Observer(cfg.Location.ExtinctionCoefficient),
 		paused:             false,
 		timeStep:           timeStep,
 		timeMultiplier:     1.0,
 		realTimeBase:       now,
+		now:                time.Now,
 		starCatalog:        catalog.NewStarCatalog(),
 		deepSkyCatalog:     catalog.NewDeepSkyCatalog(),
 		planetarySystem:    &astro.PlanetarySystem{},
 		config:             cfg,
 	}
+
+	if cfg.Time.UseUTC {
+		m.zoneMode = zoneUTC
+	}
+	m.applyZone()
+
+	// The start site may set its own magnitude limit
+	if site.MagnitudeLimit > 0 {
+		m.magnitudeLimit = site.MagnitudeLimit
+	}
+
+	// Compute positions now so the first frame isn't drawn with empty data
+	m.updatePositions()
+
+	// Commands in the startup script apply on top of the config
+	var problems []string
+	if warnings := ConfigWarnings(cfg); len(warnings) > 0 {
+		problems = append(problems, startupWarning(warnings))
+	}
+	if err := m.RunScript(config.StartupScriptPath()); err != nil && !os.IsNotExist(err) {
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		m.statusMessage = strings.Join(problems, "; ")
+	}
+
+	err = m.applyOptions(opts)
+	return m, err
+}
+
+// ConfigWarnings lists the problems with a config: those found loading it
+// and any in its keys section
+func ConfigWarnings(cfg *config.Config) []string {
+	warnings := append([]string(nil), cfg.Warnings...)
+	if _, err := loadKeyMap(cfg.Keys); err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	return warnings
+}
+
+// startupWarning fits config warnings into the status bar, pointing at
+// skyterm config check when there are several
+func startupWarning(warnings []string) string {
+	msg := "Config: " + warnings[0]
+	if len(warnings) > 1 {
+		msg += fmt.Sprintf(" (%d more; run skyterm config check)", len(warnings)-1)
+	}
+	return msg
+}
+
+// applyOptions runs the command-line options through the matching
+// commands, after the startup script so they take precedence over it. A
+// start time runs on unless paused is also given.
+func (m *Model) applyOptions(opts Options) error {
+	if opts.Site != nil {
+		m.setSite(*opts.Site)
+	}
+	if opts.Theme != "" {
+		if err := cmdTheme(m, []string{opts.Theme}); err != nil {
+			return fmt.Errorf("--theme: %w", err)
+		}
+	}
+	if opts.Time != "" {
+		if err := cmdTime(m, strings.Fields(opts.Time)); err != nil {
+			return fmt.Errorf("--time: %w", err)
+		}
+		m.setPaused(opts.Paused)
+	} else if opts.Paused {
+		m.setPaused(true)
+	}
+	if opts.FOV != 0 {
+		if err := m.setFOV(opts.FOV); err != nil {
+			return fmt.Errorf("--fov: %w", err)
+		}
+	}
+	if opts.Target != "" {
+		if err := cmdGoto(m, strings.Fields(opts.Target)); err != nil {
+			return fmt.Errorf("--target: %w", err)
+		}
+	}
+	return nil
 }
 
 func (m Model) Init() tea.Cmd {
-	return tickCmd()
+	return tickCmd(m.tickInterval())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case TickMsg:
-		realNow := time.Time(msg)
-
-		// Update current time based on pause state
-		if !m.paused {
-			// Calculate elapsed time since base with multiplier applied
-			elapsed := realNow.Sub(m.realTimeBase)
-			scaledElapsed := time.Duration(float64(elapsed) * m.timeMultiplier)
-			m.currentTime = m.realTimeBase.Add(scaledElapsed)
-
-			// Update base for next tick if running at normal speed
-			if m.timeMultiplier == 1.0 {
-				m.realTimeBase = realNow
-			}
-		}
 		// If paused, currentTime stays frozen
+		m.advanceClock(time.Time(msg))
+		m.updatePositions()
 
-		m.starCatalog.UpdatePositions(m.observer, m.currentTime)
-		m.deepSkyCatalog.UpdatePositions(m.observer, m.currentTime)
-		m.planetarySystem = astro.CalculatePlanets(m.currentTime, m.observer)
-
-		// Update following if active
-		m.UpdateFollowing()
-
-		return m, tickCmd()
+		return m, tickCmd(m.tickInterval())
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -150,13 +303,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case SnapshotSavedMsg:
+		if msg.Error != nil {
+			m.statusMessage = "Snapshot failed: " + msg.Error.Error()
+		} else {
+			m.statusMessage = "Snapshot saved to " + msg.Path
+		}
+		return m, nil
+
+	case tea.MouseMsg:
+		// Modal screens don't take mouse input
+		if m.imageViewMode || m.timeInputMode || m.gotoMode || m.searchMode || m.siteMode || m.showHelp {
+			return m, nil
+		}
+		m.handleMouse(msg)
+		return m, nil
+
 	case tea.KeyMsg:
+		m.statusMessage = ""
+
 		// Handle image viewer mode (highest priority)
 		if m.imageViewMode {
-			switch msg.String() {
-			case "esc", "v", "q":
+			if key.Matches(msg, m.keys.CloseImage) {
 				m.imageViewMode = false
-				return m, nil
 			}
 			// Ignore all other keys in viewer mode
 			return m, nil
@@ -164,82 +333,126 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Handle time input mode
 		if m.timeInputMode {
-			switch msg.String() {
-			case "esc":
+			switch {
+			case key.Matches(msg, m.keys.TimeCancel):
 				m.timeInputMode = false
 				m.timeInput = ""
 				return m, nil
-			case "enter":
-				// Parse and set time
+			case key.Matches(msg, m.keys.TimeSet):
+				// Set the time, keeping the modal open to explain a bad entry
 				if m.timeInput != "" {
-					if parsedTime, err := time.Parse("2006-01-02 15:04:05", m.timeInput); err == nil {
-						m.currentTime = parsedTime
-						m.realTimeBase = time.Now()
-						m.paused = true // Pause when manually setting time
-					} else if parsedTime, err := time.Parse("2006-01-02 15:04", m.timeInput); err == nil {
-						m.currentTime = parsedTime
-						m.realTimeBase = time.Now()
-						m.paused = true
-					} else if parsedTime, err := time.Parse("2006-01-02", m.timeInput); err == nil {
-						m.currentTime = parsedTime
-						m.realTimeBase = time.Now()
-						m.paused = true
+					if err := m.setTimeInput(m.timeInput); err != nil {
+						m.timeError = err.Error()
+						return m, nil
 					}
+					m.updatePositions()
 				}
 				m.timeInputMode = false
 				m.timeInput = ""
 				return m, nil
-			case "backspace":
+			case msg.String() == "backspace":
 				if len(m.timeInput) > 0 {
 					m.timeInput = m.timeInput[:len(m.timeInput)-1]
 				}
+				m.timeError = ""
 				return m, nil
 			default:
 				// Add character to input
 				if len(msg.String()) == 1 {
 					m.timeInput += msg.String()
+					m.timeError = ""
 				}
 				return m, nil
 			}
 		}
 
+		// Handle the command line
+		if m.cmdMode {
+			m.handleCommandKey(msg)
+			return m, nil
+		}
+
+		// Handle go-to coordinate entry
+		if m.gotoMode {
+			switch {
+			case key.Matches(msg, m.keys.GotoCancel):
+				m.gotoMode = false
+			case key.Matches(msg, m.keys.GotoSelect):
+				if m.applyGoto(m.gotoInput) {
+					m.gotoMode = false
+				}
+			case msg.String() == "backspace":
+				if len(m.gotoInput) > 0 {
+					_, size := utf8.DecodeLastRuneInString(m.gotoInput)
+					m.gotoInput = m.gotoInput[:len(m.gotoInput)-size]
+				}
+				m.gotoError = ""
+			default:
+				if len(msg.Runes) > 0 {
+					m.gotoInput += string(msg.Runes)
+					m.gotoError = ""
+				}
+			}
+			return m, nil
+		}
+
 		// Handle search mode
 		if m.searchMode {
-			switch msg.String() {
-			case "esc":
+			switch {
+			case key.Matches(msg, m.keys.SearchCancel):
 				m.searchMode = false
 				m.searchQuery = ""
+				m.searchResults = nil
 				return m, nil
-			case "enter":
+			case key.Matches(msg, m.keys.SearchSelect):
 				m.performSearch()
 				m.searchMode = false
 				m.searchQuery = ""
+				m.searchResults = nil
+				return m, nil
+			case key.Matches(msg, m.keys.SearchUp):
+				if m.searchIndex > 0 {
+					m.searchIndex--
+				}
+				return m, nil
+			case key.Matches(msg, m.keys.SearchDown):
+				if m.searchIndex < len(m.searchResults)-1 {
+					m.searchIndex++
+				}
 				return m, nil
-			case "backspace":
+			case msg.String() == "backspace":
 				if len(m.searchQuery) > 0 {
 					m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
+					m.updateSearch()
 				}
 				return m, nil
 			default:
 				// Add character to query
 				if len(msg.String()) == 1 {
 					m.searchQuery += msg.String()
+					m.updateSearch()
 				}
 				return m, nil
 			}
 		}
 
+		if m.siteMode {
+			m.handleSiteKey(msg)
+			return m, nil
+		}
+
 		// Handle help screen
 		if m.showHelp {
-			if key.Matches(msg, m.keys.CloseHelp) {
-				m.showHelp = false
-			}
+			m.handleHelpKey(msg)
 			return m, nil
 		}
 
 		switch {
 		case key.Matches(msg, m.keys.Help):
 			m.showHelp = true
+			m.helpScroll = 0
+			m.helpQuery = ""
+			m.helpSearching = false
 			return m, nil
 
 		case key.Matches(msg, m.keys.Quit):
@@ -263,8 +476,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					Star:         m.selectedObject.Star,
 					Planet:       m.selectedObject.Planet,
 					DeepSky:      m.selectedObject.DeepSky,
+					Marker:       m.selectedObject.Marker,
 					ImageLoading: true,
 				}
+				// Markers are bare coordinates with no image to look up
+				if m.selectedObject.Marker != nil {
+					m.objectInfo.ImageLoading = false
+					return m, nil
+				}
 				// Trigger async image fetch
 				return m, fetchImageCmd(m.selectedObject.Name)
 			}
@@ -277,8 +496,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case key.Matches(msg, m.keys.ViewImage):
-			// Toggle image viewer mode if we have an image
-			if m.objectInfo != nil && m.objectInfo.ImageData != "" {
+			// Toggle image viewer mode if we have an image and the theme allows it
+			if m.objectInfo != nil && m.objectInfo.ImageData != "" && theme.Current().ShowImages {
 				m.imageViewMode = !m.imageViewMode
 			}
 			return m, nil
@@ -293,74 +512,99 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case key.Matches(msg, m.keys.Command):
+			m.cmdMode = true
+			m.cmdInput = ""
+			m.cmdCandidates = nil
+			m.cmdHistoryPos = len(m.cmdHistory)
+			return m, nil
+
+		case key.Matches(msg, m.keys.Goto):
+			m.gotoMode = true
+			m.gotoInput = ""
+			m.gotoError = ""
+			return m, nil
+
+		case key.Matches(msg, m.keys.Sites):
+			m.openSitePicker()
+			return m, nil
+
 		case key.Matches(msg, m.keys.Search):
 			m.searchMode = true
 			m.searchQuery = ""
+			m.updateSearch()
 			return m, nil
 
 		// Time controls
 		case key.Matches(msg, m.keys.PauseResume):
-			m.paused = !m.paused
-			if !m.paused {
-				// Resuming - reset real time base
-				m.realTimeBase = time.Now()
-			}
+			m.setPaused(!m.paused)
 			return m, nil
 
 		case key.Matches(msg, m.keys.StepForward):
-			m.currentTime = m.currentTime.Add(m.timeStep)
-			m.paused = true
+			m.stepTime(m.timeStep)
 			return m, nil
 
 		case key.Matches(msg, m.keys.StepBackward):
-			m.currentTime = m.currentTime.Add(-m.timeStep)
-			m.paused = true
+			m.stepTime(-m.timeStep)
 			return m, nil
 
 		case key.Matches(msg, m.keys.FastStepForward):
-			m.currentTime = m.currentTime.Add(m.timeStep * 10)
-			m.paused = true
+			m.stepTime(m.timeStep * 10)
 			return m, nil
 
 		case key.Matches(msg, m.keys.FastStepBack):
-			m.currentTime = m.currentTime.Add(-m.timeStep * 10)
-			m.paused = true
+			m.stepTime(-m.timeStep * 10)
+			return m, nil
+
+		case key.Matches(msg, m.keys.Faster):
+			m.stepRate(1)
+			return m, nil
+
+		case key.Matches(msg, m.keys.Slower):
+			m.stepRate(-1)
+			return m, nil
+
+		case key.Matches(msg, m.keys.Reverse):
+			m.setRate(-m.timeMultiplier)
 			return m, nil
 
 		case key.Matches(msg, m.keys.JumpToNow):
-			m.currentTime = time.Now()
-			m.realTimeBase = time.Now()
-			m.paused = false
+			m.jumpToNow()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Zone):
+			m.setZoneMode((m.zoneMode + 1) % zoneMode(len(zoneModeNames)))
 			return m, nil
 
 		case key.Matches(msg, m.keys.SetTime):
 			m.timeInputMode = true
 			m.timeInput = ""
+			m.timeError = ""
 			return m, nil
 
 		// Navigation
 		case key.Matches(msg, m.keys.Up):
-			m.altitude = math.Min(90.0, m.altitude+m.config.Controls.PanSpeed)
+			m.altitude = math.Min(90.0, m.altitude+m.panStep())
 		case key.Matches(msg, m.keys.Down):
-			m.altitude = math.Max(-90.0, m.altitude-m.config.Controls.PanSpeed)
+			m.altitude = math.Max(-90.0, m.altitude-m.panStep())
 		case key.Matches(msg, m.keys.Left):
-			m.azimuth = math.Mod(m.azimuth-m.config.Controls.PanSpeed+360, 360)
+			m.azimuth = math.Mod(m.azimuth-m.panStep()+360, 360)
 		case key.Matches(msg, m.keys.Right):
-			m.azimuth = math.Mod(m.azimuth+m.config.Controls.PanSpeed, 360)
+			m.azimuth = math.Mod(m.azimuth+m.panStep(), 360)
 
 		// Fast navigation
 		case key.Matches(msg, m.keys.FastUp):
-			m.altitude = math.Min(90.0, m.altitude+m.config.Controls.PanSpeed*m.config.Controls.FastPanMultiplier)
+			m.altitude = math.Min(90.0, m.altitude+m.panStep()*m.config.Controls.FastPanMultiplier)
 		case key.Matches(msg, m.keys.FastDown):
-			m.altitude = math.Max(-90.0, m.altitude-m.config.Controls.PanSpeed*m.config.Controls.FastPanMultiplier)
+			m.altitude = math.Max(-90.0, m.altitude-m.panStep()*m.config.Controls.FastPanMultiplier)
 		case key.Matches(msg, m.keys.FastLeft):
-			m.azimuth = math.Mod(m.azimuth-m.config.Controls.PanSpeed*m.config.Controls.FastPanMultiplier+360, 360)
+			m.azimuth = math.Mod(m.azimuth-m.panStep()*m.config.Controls.FastPanMultiplier+360, 360)
 		case key.Matches(msg, m.keys.FastRight):
-			m.azimuth = math.Mod(m.azimuth+m.config.Controls.PanSpeed*m.config.Controls.FastPanMultiplier, 360)
+			m.azimuth = math.Mod(m.azimuth+m.panStep()*m.config.Controls.FastPanMultiplier, 360)
 
 		// Zoom
 		case key.Matches(msg, m.keys.ZoomIn):
-			m.fov = math.Max(10.0, m.fov/m.config.Controls.ZoomStep)
+			m.fov = math.Max(m.config.Controls.MinFOV, m.fov/m.config.Controls.ZoomStep)
 		case key.Matches(msg, m.keys.ZoomOut):
 			m.fov = math.Min(120.0, m.fov*m.config.Controls.ZoomStep)
 
@@ -397,6 +641,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.showDeepSky = !m.showDeepSky
 		case key.Matches(msg, m.keys.StarLabels):
 			m.showStarLabels = !m.showStarLabels
+		case key.Matches(msg, m.keys.Daylight):
+			m.showDaylight = !m.showDaylight
+		case key.Matches(msg, m.keys.Theme):
+			theme.Set(theme.Next(theme.Current()))
+			if !theme.Current().ShowImages {
+				m.imageViewMode = false
+			}
+		case key.Matches(msg, m.keys.Equipment):
+			// Cycle through each setup, then back to no overlay
+			if len(m.setups) > 0 {
+				m.setupIndex++
+				if m.setupIndex >= len(m.setups) {
+					m.setupIndex = -1
+				}
+			}
+		case key.Matches(msg, m.keys.RotateFrame):
+			m.frameRotation = math.Mod(m.frameRotation+15, 180)
+		case key.Matches(msg, m.keys.Trails):
+			m.cycleTrails()
+		case key.Matches(msg, m.keys.StarTrails):
+			m.showStarTrails = !m.showStarTrails
+			m.starTrails.Reset()
+			m.exposureTime = m.currentTime
+		case key.Matches(msg, m.keys.Minimap):
+			m.showMinimap = !m.showMinimap
+		case key.Matches(msg, m.keys.Snapshot):
+			if m.canvas != nil {
+				path := fmt.Sprintf("skyterm-%s.txt", m.currentTime.UTC().Format("20060102-150405"))
+				return m, saveSnapshotCmd(path, m.snapshotText())
+			}
 		case key.Matches(msg, m.keys.Magnitude):
 			// Cycle through magnitude limits: 3, 4, 5, 6
 			switch m.magnitudeLimit {
@@ -422,25 +696,37 @@ func (m Model) View() string {
 
 	// Show fullscreen image viewer if in viewer mode
 	if m.imageViewMode && m.objectInfo != nil {
-		return ui.RenderImageViewer(m.objectInfo, m.width, m.height+2)
+		return ui.RenderImageViewer(m.objectInfo, m.keys.contextHelp("Image Viewer"), m.width, m.height+2)
 	}
 
 	// Show time input modal if in time input mode
 	if m.timeInputMode {
-		return ui.RenderTimeInput(m.timeInput, m.width, m.height+2)
+		return ui.RenderTimeInput(m.timeInput, m.timeError, m.keys.contextHelp("Time Input"), m.width, m.height+2)
+	}
+
+	// Show coordinate entry if in go-to mode
+	if m.gotoMode {
+		return ui.RenderGotoInput(m.gotoInput, m.gotoError, m.keys.contextHelp("Go To"), m.width, m.height+2)
 	}
 
 	// Show search box if in search mode
 	if m.searchMode {
-		return ui.RenderSearchBox(m.searchQuery, m.width, m.height+2)
+		return ui.RenderSearchBox(m.searchQuery, m.searchResults, m.searchIndex, m.keys.contextHelp("Search"), m.width, m.height+2)
+	}
+
+	// Show site picker if switching sites
+	if m.siteMode {
+		return ui.RenderSitePicker(m.siteInput, m.siteChoicesView(), m.siteIndex, m.siteError, m.keys.contextHelp("Site Picker"), m.width, m.height+2)
 	}
 
 	// Show help screen if requested
 	if m.showHelp {
-		return ui.RenderHelp(m.width, m.height+2)
+		return ui.RenderHelp(m.helpView(), m.width, m.height+2)
 	}
 
-	// Clear canvas
+	// Clear canvas, painting the sky for the current Sun altitude
+	vis := m.visibility()
+	m.canvas.SetSkyColor(render.SkyColor(vis.Sky))
 	m.canvas.Clear()
 
 	// Render coordinate grid (if enabled)
@@ -448,6 +734,11 @@ func (m Model) View() string {
 		render.RenderGrid(m.canvas, m.altitude, m.azimuth, m.fov)
 	}
 
+	// Outline the site's local horizon of trees, buildings or hills
+	if len(m.observer.Horizon) > 0 {
+		render.RenderHorizon(m.canvas, m.observer.Horizon, m.altitude, m.azimuth, m.fov)
+	}
+
 	// Render constellation lines (if enabled)
 	if m.showConstellations {
 		render.RenderConstellations(
@@ -460,6 +751,11 @@ func (m Model) View() string {
 		)
 	}
 
+	// Long-exposure trails sit beneath everything drawn from here on
+	if m.showStarTrails {
+		m.starTrails.Draw(m.canvas, m.altitude, m.azimuth, m.fov)
+	}
+
 	// Render deep sky objects (if enabled)
 	if m.showDeepSky {
 		render.RenderDeepSkyObjects(
@@ -468,40 +764,60 @@ func (m Model) View() string {
 			m.altitude,
 			m.azimuth,
 			m.fov,
-			m.magnitudeLimit,
-		)
-		render.RenderDeepSkyLabels(
-			m.canvas,
-			m.deepSkyCatalog.Objects(),
-			m.altitude,
-			m.azimuth,
-			m.fov,
-			m.magnitudeLimit,
+			vis,
 		)
 	}
 
 	// Render stars
-	render.RenderStars(m.canvas, m.starCatalog.Stars(), m.altitude, m.azimuth, m.fov, m.magnitudeLimit)
-
-	// Render star labels (if enabled)
-	if m.showStarLabels {
-		render.RenderStarLabels(m.canvas, m.starCatalog.Stars(), m.altitude, m.azimuth, m.fov, m.magnitudeLimit)
-	}
+	render.RenderStars(m.canvas, m.starCatalog.Stars(), m.altitude, m.azimuth, m.fov, vis)
 
 	// Render planets (if enabled)
 	if m.showPlanets {
-		render.RenderPlanets(m.canvas, m.planetarySystem, m.altitude, m.azimuth, m.fov)
+		render.RenderPlanets(m.canvas, m.planetarySystem, m.altitude, m.azimuth, m.fov, vis)
+	}
+
+	// Outline the equipment field around the selection or the view center
+	if setup, ok := m.activeSetup(); ok {
+		targetAlt, targetAz, selected := m.selectedPosition()
+		if !selected {
+			targetAlt, targetAz = m.altitude, m.azimuth
+		}
+		render.RenderFieldOfView(m.canvas, setup, targetAlt, targetAz, m.altitude, m.azimuth, m.fov)
+	}
+
+	// Lay out all labels in one pass so they don't collide
+	labels := render.NewLabelLayout(m.canvas)
+
+	render.RenderMarker(m.canvas, labels, m.marker, m.altitude, m.azimuth, m.fov)
+
+	if m.trailMode != trailsOff {
+		render.RenderTrails(m.canvas, labels, m.trails, m.altitude, m.azimuth, m.fov)
 	}
 
-	// Render planet labels (if enabled)
 	if m.showPlanetLabels {
-		render.RenderPlanetLabels(m.canvas, m.planetarySystem, m.altitude, m.azimuth, m.fov)
+		render.RenderPlanetLabels(m.canvas, labels, m.planetarySystem, m.altitude, m.azimuth, m.fov, vis)
+	}
+
+	if m.showStarLabels {
+		render.RenderStarLabels(m.canvas, labels, m.starCatalog.Stars(), m.altitude, m.azimuth, m.fov, vis)
+	}
+
+	if m.showDeepSky {
+		render.RenderDeepSkyLabels(
+			m.canvas,
+			labels,
+			m.deepSkyCatalog.Objects(),
+			m.altitude,
+			m.azimuth,
+			m.fov,
+			vis,
+		)
 	}
 
-	// Render constellation names (if enabled)
 	if m.showNames {
 		render.RenderConstellationLabels(
 			m.canvas,
+			labels,
 			m.starCatalog.Stars(),
 			catalog.GetConstellationLabels(),
 			m.altitude,
@@ -510,15 +826,30 @@ func (m Model) View() string {
 		)
 	}
 
+	labels.Draw()
+
+	// The minimap inset goes on top of everything in its corner
+	if m.showMinimap {
+		selectedAlt, selectedAz, selected := m.selectedPosition()
+		render.RenderMinimap(m.canvas, m.starCatalog.Stars(), selectedAlt, selectedAz, selected, m.altitude, m.azimuth, m.fov)
+	}
+
+	if tooltip, ok := m.hoverTooltip(); ok {
+		render.RenderTooltip(m.canvas, m.mouse.x, m.mouse.y, tooltip)
+	}
+
 	// Build the view
 	skyView := m.canvas.Render()
 	statusBar := m.renderStatusBar()
+	if m.cmdMode {
+		statusBar = ui.RenderCommandLine(m.cmdInput, m.cmdCandidates, m.keys.contextHelp("Command Line"), m.width)
+	}
 
 	view := lipgloss.JoinVertical(lipgloss.Left, skyView, statusBar)
 
 	// Overlay info panel if requested
 	if m.showInfo && m.objectInfo != nil {
-		infoPanel := ui.RenderInfoPanel(m.objectInfo, m.observer, m.width, m.height+2)
+		infoPanel := ui.RenderInfoPanel(m.objectInfo, m.observer, m.currentTime.In(m.displayZone()), m.width, m.height+2)
 		// Overlay the panel on top of the view
 		view = lipgloss.Place(
 			m.width,
@@ -533,21 +864,126 @@ func (m Model) View() string {
 	return view
 }
 
+// updatePositions recomputes every object's position for the current time
+func (m *Model) updatePositions() {
+	m.starCatalog.UpdatePositions(m.observer, m.currentTime)
+	m.deepSkyCatalog.UpdatePositions(m.observer, m.currentTime)
+	m.planetarySystem = astro.CalculatePlanets(m.currentTime, m.observer)
+	m.sky = astro.NewSkyConditions(m.planetarySystem, m.currentTime)
+	if m.marker != nil {
+		m.marker.UpdatePosition(m.observer, m.currentTime)
+	}
+	for i := range m.trails {
+		m.trails[i].UpdatePositions(m.observer, m.currentTime)
+	}
+	m.accumulateStarTrails()
+
+	// Keep the selection current and follow it if active
+	m.refreshSelection()
+	m.UpdateFollowing()
+}
+
+// visibility returns the magnitude limits for rendering and selection
+func (m *Model) visibility() render.Visibility {
+	vis := render.Visibility{Limit: m.magnitudeLimit, Observer: m.observer}
+	if m.showDaylight {
+		vis.Sky = &m.sky
+	}
+	return vis
+}
+
+// maxExposureJump is the largest time change added to a star trail
+// exposure; bigger jumps start a new exposure
+const maxExposureJump = 24 * time.Hour
+
+// accumulateStarTrails extends the exposure to the current time
+func (m *Model) accumulateStarTrails() {
+	if !m.showStarTrails || m.canvas == nil {
+		return
+	}
+
+	from := m.exposureTime
+	m.exposureTime = m.currentTime
+	if m.currentTime.Sub(from).Abs() > maxExposureJump {
+		m.starTrails.Reset()
+		return
+	}
+
+	m.starTrails.Accumulate(m.canvas.Width, m.canvas.Height, m.starCatalog.Stars(), m.observer,
+		from, m.currentTime, m.altitude, m.azimuth, m.fov, m.visibility())
+}
+
+// snapshotText returns the last drawn sky view as plain text with a short
+// header describing it
+func (m *Model) snapshotText() string {
+	header := fmt.Sprintf("skyterm %s │ %s │ Alt %.1f° Az %.1f° FOV %s\n",
+		m.currentTime.UTC().Format("2006-01-02 15:04:05 UTC"), m.observer.Name,
+		m.altitude, m.azimuth, optics.FormatField(m.fov))
+	if m.showStarTrails {
+		header += fmt.Sprintf("Star trail exposure: %s\n", formatExposure(m.starTrails.Exposure))
+	}
+	return header + "\n" + m.canvas.PlainText()
+}
+
+// formatExposure formats an exposure length as hours and minutes
+func formatExposure(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// panStep returns the pan distance in degrees. It shrinks with the field
+// of view below the default 60° so narrow fields don't jump out of view.
+func (m *Model) panStep() float64 {
+	return m.config.Controls.PanSpeed * math.Min(1.0, m.fov/60.0)
+}
+
+// activeSetup returns the equipment setup shown as an overlay, with the
+// user's frame rotation applied
+func (m *Model) activeSetup() (optics.Setup, bool) {
+	if m.setupIndex < 0 || m.setupIndex >= len(m.setups) {
+		return optics.Setup{}, false
+	}
+
+	setup := m.setups[m.setupIndex]
+	if !setup.Circular {
+		setup.Rotation += m.frameRotation
+	}
+	return setup, true
+}
+
+// coordPrecision returns the decimals needed to show view coordinates at
+// the current zoom
+func (m Model) coordPrecision() int {
+	switch {
+	case m.fov < 1:
+		return 3
+	case m.fov < 10:
+		return 2
+	default:
+		return 1
+	}
+}
+
 func (m Model) renderStatusBar() string {
+	th := theme.Current()
 	style := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
-		Background(lipgloss.Color("235"))
+		Foreground(th.StatusText).
+		Background(th.StatusBackground)
 
-	// Format current time
-	timeStr := m.currentTime.Format("2006-01-02 15:04:05 MST")
-	if m.config.Time.UseUTC {
-		timeStr = m.currentTime.UTC().Format("2006-01-02 15:04:05 UTC")
-	}
+	// Format current time in the display zone
+	timeStr := m.currentTime.In(m.displayZone()).Format("2006-01-02 15:04:05 MST")
 
-	// Add paused indicator
-	pausedIndicator := ""
+	// Add paused and time rate indicators
+	var clockState []string
 	if m.paused {
-		pausedIndicator = " [PAUSED]"
+		clockState = append(clockState, "PAUSED")
+	}
+	if m.timeMultiplier != 1 {
+		clockState = append(clockState, formatRate(m.timeMultiplier))
+	}
+	pausedIndicator := ""
+	if len(clockState) > 0 {
+		pausedIndicator = " [" + strings.Join(clockState, " ") + "]"
 	}
 
 	// Get sidereal time
@@ -577,22 +1013,47 @@ func (m Model) renderStatusBar() string {
 	if m.showStarLabels {
 		toggles += "S"
 	}
+	if m.trailMode != trailsOff {
+		toggles += "Y"
+	}
+	if m.showMinimap {
+		toggles += "M"
+	}
+	if m.showStarTrails {
+		toggles += "X " + formatExposure(m.starTrails.Exposure)
+	}
 	if toggles != "" {
 		toggles = " [" + toggles + "]"
 	}
 
 	// Build status bar sections
-	left := fmt.Sprintf(" Alt: %.1f° Az: %.1f° │ FOV: %.1f°%s", m.altitude, m.azimuth, m.fov, toggles)
+	left := fmt.Sprintf(" Alt: %.*f° Az: %.*f° │ FOV: %s%s",
+		m.coordPrecision(), m.altitude, m.coordPrecision(), m.azimuth, optics.FormatField(m.fov), toggles)
 	center := fmt.Sprintf(" %s%s │ LST: %s", timeStr, pausedIndicator, lstStr)
-	right := fmt.Sprintf("Mag: %.1f │ %s ", m.magnitudeLimit, m.observer.Name)
+	if m.showDaylight {
+		center += " │ " + m.sky.Twilight().String() + " "
+	}
+	if m.statusMessage != "" {
+		center = " " + m.statusMessage + " "
+	}
+	magStr := fmt.Sprintf("%.1f", m.magnitudeLimit)
+	if limit := m.visibility().LimitAt(m.altitude, m.azimuth); limit < m.magnitudeLimit-0.05 {
+		// Show the sky-limited magnitude at the view center alongside the setting
+		magStr = fmt.Sprintf("%.1f/%.1f", limit, m.magnitudeLimit)
+	}
+	equipment := ""
+	if setup, ok := m.activeSetup(); ok {
+		equipment = setup.Summary() + " │ "
+	}
+	right := fmt.Sprintf("%sMag: %s │ %s ", equipment, magStr, m.observer.Name)
 
 	// Calculate padding
 	usedWidth := lipgloss.Width(left) + lipgloss.Width(center) + lipgloss.Width(right)
 	padding := m.width - usedWidth
 	if padding < 0 {
 		// If too wide, simplify
-		left = fmt.Sprintf(" %.1f°/%.1f°%s", m.altitude, m.azimuth, toggles)
-		right = fmt.Sprintf("%.1f ", m.magnitudeLimit)
+		left = fmt.Sprintf(" %.*f°/%.*f°%s", m.coordPrecision(), m.altitude, m.coordPrecision(), m.azimuth, toggles)
+		right = equipment + magStr + " "
 		usedWidth = lipgloss.Width(left) + lipgloss.Width(center) + lipgloss.Width(right)
 		padding = m.width - usedWidth
 	}
@@ -609,11 +1070,11 @@ func (m Model) renderStatusBar() string {
 	rightPad := padding - leftPad
 
 	leftSpacer := lipgloss.NewStyle().
-		Background(lipgloss.Color("235")).
+		Background(th.StatusBackground).
 		Render(lipgloss.PlaceHorizontal(leftPad, lipgloss.Left, ""))
 
 	rightSpacer := lipgloss.NewStyle().
-		Background(lipgloss.Color("235")).
+		Background(th.StatusBackground).
 		Render(lipgloss.PlaceHorizontal(rightPad, lipgloss.Left, ""))
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, leftBar, leftSpacer, centerBar, rightSpacer, rightBar)
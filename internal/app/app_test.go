@@ -0,0 +1,110 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/craigderington/skyterm/internal/config"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// newTestModel builds a model from the default config with no startup
+// script
+func newTestModel(t *testing.T) *Model {
+	t.Helper()
+	return newTestModelWith(t, config.DefaultConfig(), Options{})
+}
+
+// newTestModelWith builds a model from cfg and opts with no startup script
+func newTestModelWith(t *testing.T, cfg *config.Config, opts Options) *Model {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	m, err := New(cfg, opts)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return &m
+}
+
+// newScriptModel builds a model from cfg and opts after writing script as
+// the startup script
+func newScriptModel(t *testing.T, cfg *config.Config, opts Options, script string) *Model {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := config.StartupScriptPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(cfg, opts)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return &m
+}
+
+func TestOptionsOverrideStartupScript(t *testing.T) {
+	prev := theme.Current()
+	t.Cleanup(func() { theme.Set(prev) })
+
+	cfg := config.DefaultConfig()
+	cfg.Sites = []config.SiteConfig{
+		{Name: "Dark Site", Latitude: 38.5, Longitude: -107.7, Elevation: 2400},
+		{Name: "Backyard", Latitude: 35.2, Longitude: -111.6, Elevation: 2100},
+	}
+	site, err := StartSite(cfg, SiteFlags{Site: "Dark Site"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	script := "site Backyard\ntheme monochrome\n"
+	m := newScriptModel(t, cfg, Options{Site: site, Theme: "night"}, script)
+	if m.observer.Name != "Dark Site" {
+		t.Errorf("observing from %q, want the --site choice Dark Site", m.observer.Name)
+	}
+	if got := theme.Current().Name; got != "night" {
+		t.Errorf("theme = %q, want the --theme choice night", got)
+	}
+
+	// Without options the script's choices stand
+	m = newScriptModel(t, cfg, Options{}, script)
+	if m.observer.Name != "Backyard" {
+		t.Errorf("observing from %q, want the script's Backyard", m.observer.Name)
+	}
+	if got := theme.Current().Name; got != "monochrome" {
+		t.Errorf("theme = %q, want the script's monochrome", got)
+	}
+}
+
+func TestBadThemeOption(t *testing.T) {
+	prev := theme.Current()
+	t.Cleanup(func() { theme.Set(prev) })
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if _, err := New(config.DefaultConfig(), Options{Theme: "plaid"}); err == nil || !strings.HasPrefix(err.Error(), "--theme: ") {
+		t.Errorf("error = %v, want a --theme error", err)
+	}
+}
+
+func TestStartupProblemsKeepConfigWarnings(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Warnings = []string{"controls.zoom_step: 0.5 must be greater than 1; using 1.2"}
+
+	m := newScriptModel(t, cfg, Options{}, "mag 6\nfov 500\n")
+	for _, want := range []string{"Config: controls.zoom_step", "startup:2: field of view"} {
+		if !strings.Contains(m.statusMessage, want) {
+			t.Errorf("status %q lacks %q", m.statusMessage, want)
+		}
+	}
+
+	// Commands the script runs don't hide the warnings either
+	m = newScriptModel(t, cfg, Options{}, "loc 35.2 -111.6\n")
+	if !strings.HasPrefix(m.statusMessage, "Config: controls.zoom_step") {
+		t.Errorf("status = %q, want the config warning", m.statusMessage)
+	}
+}
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/craigderington/skyterm/internal/astro"
+)
+
+// siderealDayRate runs one sidereal day, a full turn of the sky, per second
+const siderealDayRate = 86164.0905
+
+// ratePresets are the speeds stepped through by the faster and slower keys
+var ratePresets = []float64{1, 10, 60, 600, siderealDayRate}
+
+// zoneMode selects the time zone times are shown and typed in
+type zoneMode int
+
+const (
+	zoneSite    zoneMode = iota // The observer's zone
+	zoneMachine                 // This machine's zone
+	zoneUTC
+)
+
+// zoneModeNames are the names used by :zone, in cycling order
+var zoneModeNames = []string{"site", "local", "utc"}
+
+// Tick intervals: once a second at real time, down to minTick while
+// animating so the sky moves smoothly
+const (
+	maxTick = time.Second
+	minTick = 50 * time.Millisecond
+)
+
+// advanceClock moves the simulated time on by the real time elapsed since
+// the last tick or resume, scaled by the multiplier
+func (m *Model) advanceClock(realNow time.Time) {
+	if !m.paused {
+		elapsed := realNow.Sub(m.realTimeBase)
+		m.currentTime = m.currentTime.Add(time.Duration(float64(elapsed) * m.timeMultiplier))
+	}
+	m.realTimeBase = realNow
+}
+
+// setPaused stops or restarts the clock. Pausing first catches up the time
+// elapsed since the last tick; resuming counts from now.
+func (m *Model) setPaused(paused bool) {
+	m.advanceClock(m.now())
+	m.paused = paused
+}
+
+// setRate changes the multiplier from now on
+func (m *Model) setRate(rate float64) {
+	m.advanceClock(m.now())
+	m.timeMultiplier = rate
+}
+
+// stepRate moves to the next preset speed up (dir > 0) or down, keeping
+// the direction of time
+func (m *Model) stepRate(dir int) {
+	sign := math.Copysign(1, m.timeMultiplier)
+	speed := math.Abs(m.timeMultiplier)
+
+	next := speed
+	if dir > 0 {
+		next = ratePresets[len(ratePresets)-1]
+		for _, p := range ratePresets {
+			if p > speed*1.0001 {
+				next = p
+				break
+			}
+		}
+	} else {
+		next = ratePresets[0]
+		for i := len(ratePresets) - 1; i >= 0; i-- {
+			if ratePresets[i] < speed*0.9999 {
+				next = ratePresets[i]
+				break
+			}
+		}
+	}
+	m.setRate(sign * next)
+}
+
+// stepTime moves the clock by d and pauses it there
+func (m *Model) stepTime(d time.Duration) {
+	m.setPaused(true)
+	m.currentTime = m.currentTime.Add(d)
+}
+
+// jumpToNow returns to the present running at real time
+func (m *Model) jumpToNow() {
+	m.currentTime = m.now()
+	m.realTimeBase = m.currentTime
+	m.timeMultiplier = 1
+	m.paused = false
+	m.applyZone()
+}
+
+// displayZone is the zone every time is shown and typed in
+func (m *Model) displayZone() *time.Location {
+	switch m.zoneMode {
+	case zoneMachine:
+		return time.Local
+	case zoneUTC:
+		return time.UTC
+	}
+	return m.observer.LocalZone()
+}
+
+// applyZone moves the clock and trail times into the display zone, after
+// the mode or the site changes
+func (m *Model) applyZone() {
+	zone := m.displayZone()
+	m.currentTime = m.currentTime.In(zone)
+	for i := range m.trails {
+		for j := range m.trails[i].Points {
+			m.trails[i].Points[j].Time = m.trails[i].Points[j].Time.In(zone)
+		}
+	}
+}
+
+// setZoneMode switches the display zone and says which zone is in use
+func (m *Model) setZoneMode(mode zoneMode) {
+	m.zoneMode = mode
+	m.applyZone()
+	switch mode {
+	case zoneSite:
+		m.statusMessage = "Site time: " + m.displayZone().String()
+	case zoneMachine:
+		m.statusMessage = "This machine's time: " + time.Local.String()
+	case zoneUTC:
+		m.statusMessage = "UTC"
+	}
+}
+
+// tickInterval is the time to the next tick: shorter the faster the
+// simulated clock runs
+func (m *Model) tickInterval() time.Duration {
+	speed := math.Abs(m.timeMultiplier)
+	if m.paused || speed <= 1 {
+		return maxTick
+	}
+	return max(minTick, time.Duration(float64(maxTick)/speed))
+}
+
+// formatRate describes a multiplier for the status bar, e.g. "60×", "-10×"
+// or "1 sd/s" for one sidereal day per second
+func formatRate(rate float64) string {
+	sign := ""
+	if rate < 0 {
+		sign = "-"
+	}
+	speed := math.Abs(rate)
+	if math.Abs(speed-siderealDayRate) < 1 {
+		return sign + "1 sd/s"
+	}
+	return fmt.Sprintf("%s%g×", sign, speed)
+}
+
+// setTimeInput moves the clock to a typed time and pauses it there. "now"
+// returns to the present at real time instead.
+func (m *Model) setTimeInput(input string) error {
+	if strings.EqualFold(strings.TrimSpace(input), "now") {
+		m.jumpToNow()
+		return nil
+	}
+
+	ctx := m.timeContext()
+	t, err := astro.ParseTime(input, ctx)
+	if err != nil {
+		return err
+	}
+	m.setPaused(true)
+	m.currentTime = t.In(ctx.Location)
+	return nil
+}
+
+// timeContext is what typed times are read against: times without a zone
+// are in the zone the status bar shows
+func (m *Model) timeContext() astro.TimeContext {
+	return astro.TimeContext{
+		Now:      m.now(),
+		Current:  m.currentTime,
+		Location: m.displayZone(),
+		Observer: m.observer,
+	}
+}
@@ -0,0 +1,273 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/craigderington/skyterm/internal/config"
+)
+
+// fixedClock is a real-time source the tests move by hand
+type fixedClock struct{ t time.Time }
+
+func (c *fixedClock) now() time.Time          { return c.t }
+func (c *fixedClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+var (
+	realStart = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	simStart  = time.Date(2025, 6, 21, 22, 0, 0, 0, time.UTC)
+)
+
+// newClockModel returns a model running at real time from simStart, reading
+// the real time from clock
+func newClockModel(t *testing.T) (*Model, *fixedClock) {
+	t.Helper()
+	m := newTestModel(t)
+	clock := &fixedClock{t: realStart}
+	m.now = clock.now
+	m.currentTime = simStart
+	m.realTimeBase = realStart
+	m.timeMultiplier = 1
+	m.paused = false
+	return m, clock
+}
+
+// tick delivers a tick at the clock's current time
+func tick(m *Model, clock *fixedClock) {
+	m.advanceClock(clock.now())
+}
+
+func wantSim(t *testing.T, m *Model, offset time.Duration) {
+	t.Helper()
+	if want := simStart.Add(offset); !m.currentTime.Equal(want) {
+		t.Errorf("simulated time = %v, want start + %v (got start + %v)",
+			m.currentTime.UTC(), offset, m.currentTime.Sub(simStart))
+	}
+}
+
+func TestAdvanceClockScalesByRate(t *testing.T) {
+	m, clock := newClockModel(t)
+	m.setRate(60)
+	clock.advance(time.Second)
+	tick(m, clock)
+	wantSim(t, m, time.Minute)
+
+	m.setRate(-60)
+	clock.advance(2 * time.Second)
+	tick(m, clock)
+	wantSim(t, m, -time.Minute)
+}
+
+func TestRateChangeAppliesFromNow(t *testing.T) {
+	m, clock := newClockModel(t)
+	clock.advance(5 * time.Second)
+	m.setRate(60) // The first five seconds still run at real time
+	wantSim(t, m, 5*time.Second)
+
+	clock.advance(time.Second)
+	tick(m, clock)
+	wantSim(t, m, 5*time.Second+time.Minute)
+}
+
+func TestPauseStepResume(t *testing.T) {
+	m, clock := newClockModel(t)
+	clock.advance(10 * time.Second)
+	m.setPaused(true) // Catches up the time since the last tick
+	wantSim(t, m, 10*time.Second)
+
+	clock.advance(10 * time.Second)
+	tick(m, clock)
+	wantSim(t, m, 10*time.Second)
+
+	m.stepTime(time.Minute)
+	if !m.paused {
+		t.Error("stepping should leave the clock paused")
+	}
+	wantSim(t, m, 10*time.Second+time.Minute)
+
+	// Resuming counts from now, not from the last tick before the pause
+	clock.advance(20 * time.Second)
+	m.setPaused(false)
+	wantSim(t, m, 10*time.Second+time.Minute)
+	clock.advance(time.Second)
+	tick(m, clock)
+	wantSim(t, m, 11*time.Second+time.Minute)
+}
+
+func TestStepWhileRunningPauses(t *testing.T) {
+	m, clock := newClockModel(t)
+	clock.advance(3 * time.Second)
+	m.stepTime(-time.Hour)
+	wantSim(t, m, 3*time.Second-time.Hour)
+
+	clock.advance(time.Minute)
+	tick(m, clock)
+	wantSim(t, m, 3*time.Second-time.Hour)
+}
+
+func TestJumpToNow(t *testing.T) {
+	m, clock := newClockModel(t)
+	m.setRate(-600)
+	m.stepTime(72 * time.Hour)
+
+	clock.advance(time.Minute)
+	m.jumpToNow()
+	if m.paused || m.timeMultiplier != 1 {
+		t.Errorf("after jump: paused %v at %v×, want running at 1×", m.paused, m.timeMultiplier)
+	}
+	if !m.currentTime.Equal(clock.now()) {
+		t.Errorf("after jump: %v, want %v", m.currentTime, clock.now())
+	}
+
+	clock.advance(2 * time.Second)
+	tick(m, clock)
+	if want := clock.now(); !m.currentTime.Equal(want) {
+		t.Errorf("after tick: %v, want %v", m.currentTime, want)
+	}
+}
+
+func TestStepRate(t *testing.T) {
+	tests := []struct {
+		rate float64
+		dir  int
+		want float64
+	}{
+		{1, 1, 10},
+		{10, 1, 60},
+		{60, 1, 600},
+		{600, 1, siderealDayRate},
+		{siderealDayRate, 1, siderealDayRate},
+		{45, 1, 60},
+		{-60, 1, -600},
+		{600, -1, 60},
+		{10, -1, 1},
+		{1, -1, 1},
+		{45, -1, 10},
+		{-siderealDayRate, -1, -600},
+	}
+	for _, tt := range tests {
+		m, _ := newClockModel(t)
+		m.timeMultiplier = tt.rate
+		m.stepRate(tt.dir)
+		if m.timeMultiplier != tt.want {
+			t.Errorf("stepRate(%d) from %v = %v, want %v", tt.dir, tt.rate, m.timeMultiplier, tt.want)
+		}
+	}
+}
+
+func TestTickInterval(t *testing.T) {
+	tests := []struct {
+		rate   float64
+		paused bool
+		want   time.Duration
+	}{
+		{1, false, maxTick},
+		{0.5, false, maxTick},
+		{600, true, maxTick},
+		{10, false, 100 * time.Millisecond},
+		{-10, false, 100 * time.Millisecond},
+		{60, false, minTick},
+		{siderealDayRate, false, minTick},
+	}
+	for _, tt := range tests {
+		m, _ := newClockModel(t)
+		m.timeMultiplier = tt.rate
+		m.paused = tt.paused
+		if got := m.tickInterval(); got != tt.want {
+			t.Errorf("tickInterval at %v× (paused %v) = %v, want %v", tt.rate, tt.paused, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	tests := []struct {
+		rate float64
+		want string
+	}{
+		{1, "1×"},
+		{60, "60×"},
+		{-10, "-10×"},
+		{1.5, "1.5×"},
+		{siderealDayRate, "1 sd/s"},
+		{-siderealDayRate, "-1 sd/s"},
+	}
+	for _, tt := range tests {
+		if got := formatRate(tt.rate); got != tt.want {
+			t.Errorf("formatRate(%v) = %q, want %q", tt.rate, got, tt.want)
+		}
+	}
+}
+
+// useMachineZone sets this machine's zone for the rest of the test
+func useMachineZone(t *testing.T, zone *time.Location) {
+	t.Helper()
+	local := time.Local
+	time.Local = zone
+	t.Cleanup(func() { time.Local = local })
+}
+
+func TestZoneCycle(t *testing.T) {
+	useMachineZone(t, time.FixedZone("MCH", 5*3600+1800))
+	cfg := config.DefaultConfig()
+	cfg.Location.TimeZone = "America/Denver"
+	m := newTestModelWith(t, cfg, Options{})
+	m.setPaused(true)
+	m.currentTime = time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC)
+
+	zoneKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")}
+	steps := []struct {
+		message string // Said on switching
+		clock   string // Clock in the status bar
+		zone    string // Zone typed times are read in
+	}{
+		{"", "2025-07-01 12:00:00 MDT", "America/Denver"},
+		{"This machine's time: MCH", "2025-07-01 23:30:00 MCH", "MCH"},
+		{"UTC", "2025-07-01 18:00:00 UTC", "UTC"},
+		{"Site time: America/Denver", "2025-07-01 12:00:00 MDT", "America/Denver"},
+	}
+	for i, step := range steps {
+		if i > 0 {
+			updated, _ := m.Update(zoneKey)
+			next := updated.(Model)
+			m = &next
+		}
+		if m.statusMessage != step.message {
+			t.Errorf("step %d: message %q, want %q", i, m.statusMessage, step.message)
+		}
+		m.statusMessage = "" // The message takes the clock's place
+		if bar := m.renderStatusBar(); !strings.Contains(bar, step.clock) {
+			t.Errorf("step %d: status bar lacks %q: %q", i, step.clock, bar)
+		}
+		if got := m.timeContext().Location.String(); got != step.zone {
+			t.Errorf("step %d: times typed in %s, want %s", i, got, step.zone)
+		}
+	}
+
+	// A time typed without a zone is read in the zone shown
+	m.setZoneMode(zoneSite)
+	if err := m.setTimeInput("2025-07-02 21:00"); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 7, 3, 3, 0, 0, 0, time.UTC); !m.currentTime.Equal(want) {
+		t.Errorf("typed site time = %v, want %v", m.currentTime.UTC(), want)
+	}
+}
+
+func TestUnknownSiteZoneUsesMachineZone(t *testing.T) {
+	useMachineZone(t, time.FixedZone("MCH", -3*3600))
+	m := newTestModel(t)
+	m.setPaused(true)
+	m.setSite(config.SiteConfig{Name: "Nowhere", Latitude: 10, Longitude: 20, TimeZone: "Mars/Olympus_Mons"})
+	m.currentTime = time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC)
+	m.statusMessage = ""
+
+	if got := m.displayZone(); got != time.Local {
+		t.Errorf("site zone = %v, want the machine's zone", got)
+	}
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "2025-07-01 15:00:00 MCH") {
+		t.Error("status bar not in the machine's zone")
+	}
+}
@@ -0,0 +1,507 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/theme"
+	"github.com/craigderington/skyterm/internal/ui"
+)
+
+// command is one ":" command
+type command struct {
+	name  string
+	usage string
+	run   func(m *Model, args []string) error
+
+	// complete lists candidates for the argument being typed, given the
+	// arguments before it
+	complete func(m *Model, args []string) []string
+}
+
+// commands lists every command in the order shown by :help. It is filled
+// in by init because :help refers back to it.
+var commands []command
+
+func init() {
+	commands = []command{
+		{name: "mag", usage: "mag <limit>", run: cmdMag},
+		{name: "fov", usage: "fov <degrees>", run: cmdFOV},
+		{name: "goto", usage: "goto <object|coordinates>", run: cmdGoto, complete: completeObjects},
+		{name: "time", usage: "time <date [time] [zone]|+2h|JD n|sunset|now>", run: cmdTime, complete: completeWords("now", "sunset", "sunrise", "moonrise", "moonset", "dusk", "dawn", "tomorrow")},
+		{name: "speed", usage: "speed <rate>x", run: cmdSpeed, complete: completeWords("1x", "10x", "60x", "600x", "sidereal", "-1x", "-60x", "-sidereal")},
+		{name: "zone", usage: "zone <site|local|utc>", run: cmdZone, complete: completeWords(zoneModeNames...)},
+		{name: "pause", usage: "pause", run: cmdPause},
+		{name: "play", usage: "play", run: cmdPlay},
+		{name: "loc", usage: "loc <lat> <lon> [elevation]", run: cmdLoc},
+		{name: "site", usage: "site <name|place>", run: cmdSite, complete: completeSites},
+		{name: "set", usage: "set <option> [on|off|toggle]", run: cmdSet, complete: completeSet},
+		{name: "theme", usage: "theme <name>", run: cmdTheme, complete: completeThemes},
+		{name: "help", usage: "help [command]", run: cmdHelp, complete: completeCommands},
+	}
+}
+
+// displayOptions maps :set option names to the toggles they control
+var displayOptions = map[string]func(m *Model) *bool{
+	"grid":         func(m *Model) *bool { return &m.showGrid },
+	"lines":        func(m *Model) *bool { return &m.showConstellations },
+	"names":        func(m *Model) *bool { return &m.showNames },
+	"planets":      func(m *Model) *bool { return &m.showPlanets },
+	"planetlabels": func(m *Model) *bool { return &m.showPlanetLabels },
+	"deepsky":      func(m *Model) *bool { return &m.showDeepSky },
+	"starlabels":   func(m *Model) *bool { return &m.showStarLabels },
+	"daylight":     func(m *Model) *bool { return &m.showDaylight },
+	"minimap":      func(m *Model) *bool { return &m.showMinimap },
+}
+
+// RunCommand parses and runs one command line. A leading ":" is optional;
+// blank lines and lines starting with "#" do nothing.
+func (m *Model) RunCommand(line string) error {
+	line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), ":"))
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	fields := strings.Fields(line)
+	cmd, err := lookupCommand(fields[0])
+	if err != nil {
+		return err
+	}
+	return cmd.run(m, fields[1:])
+}
+
+// RunScript runs a file of commands, one per line. It stops at the first
+// failing line and reports it with its line number.
+func (m *Model) RunScript(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if err := m.RunCommand(scanner.Text()); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// lookupCommand finds a command by name or unambiguous prefix
+func lookupCommand(name string) (command, error) {
+	name = strings.ToLower(name)
+
+	var matches []command
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, nil
+		}
+		if strings.HasPrefix(cmd.name, name) {
+			matches = append(matches, cmd)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return command{}, fmt.Errorf("unknown command %q", name)
+	case 1:
+		return matches[0], nil
+	}
+
+	names := make([]string, len(matches))
+	for i, cmd := range matches {
+		names[i] = cmd.name
+	}
+	return command{}, fmt.Errorf("ambiguous command %q: %s", name, strings.Join(names, ", "))
+}
+
+// completeCommand completes the last word of a command line. It returns the
+// new line and, when several candidates remain, the candidates.
+func (m *Model) completeCommand(line string) (string, []string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasSuffix(line, " ") {
+		fields = append(fields, "")
+	}
+	word := fields[len(fields)-1]
+	prefix := line[:len(line)-len(word)]
+
+	var candidates []string
+	if len(fields) == 1 {
+		for _, cmd := range commands {
+			candidates = append(candidates, cmd.name)
+		}
+	} else if cmd, err := lookupCommand(fields[0]); err == nil && cmd.complete != nil {
+		candidates = cmd.complete(m, fields[1:len(fields)-1])
+	}
+
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(strings.ToLower(c), strings.ToLower(word)) {
+			matches = append(matches, c)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return line, nil
+	case 1:
+		return prefix + matches[0] + " ", nil
+	}
+
+	// Extend to the longest prefix the candidates share
+	common := matches[0]
+	for _, c := range matches[1:] {
+		for !strings.HasPrefix(strings.ToLower(c), strings.ToLower(common)) {
+			_, size := utf8.DecodeLastRuneInString(common)
+			common = common[:len(common)-size]
+		}
+	}
+	if len(common) > len(word) {
+		return prefix + common, matches
+	}
+	return line, matches
+}
+
+// completeCommands completes command names for :help
+func completeCommands(_ *Model, args []string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+	names := make([]string, len(commands))
+	for i, cmd := range commands {
+		names[i] = cmd.name
+	}
+	return names
+}
+
+// completeWords completes from a fixed list
+func completeWords(words ...string) func(*Model, []string) []string {
+	return func(*Model, []string) []string { return words }
+}
+
+// completeObjects completes object names for :goto
+func completeObjects(m *Model, args []string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+	var names []string
+	for _, item := range m.searchItems() {
+		names = append(names, item.Name)
+	}
+	return names
+}
+
+// completeSet completes option names, then on/off
+func completeSet(_ *Model, args []string) []string {
+	if len(args) == 0 {
+		var names []string
+		for name := range displayOptions {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return names
+	}
+	if len(args) == 1 {
+		return []string{"on", "off", "toggle"}
+	}
+	return nil
+}
+
+// completeThemes completes built-in theme names
+func completeThemes(_ *Model, args []string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+	var names []string
+	for _, t := range theme.Builtin() {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// parseNumbers reads exactly n numeric arguments
+func parseNumbers(args []string, names ...string) ([]float64, error) {
+	if len(args) != len(names) {
+		return nil, fmt.Errorf("expected %s", strings.Join(names, " "))
+	}
+	values := make([]float64, len(args))
+	for i, arg := range args {
+		v, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a number", names[i], arg)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func cmdMag(m *Model, args []string) error {
+	values, err := parseNumbers(args, "<limit>")
+	if err != nil {
+		return err
+	}
+	if values[0] < -2 || values[0] > 12 {
+		return fmt.Errorf("magnitude limit %.1f is out of range (-2 to 12)", values[0])
+	}
+	m.magnitudeLimit = values[0]
+	return nil
+}
+
+func cmdFOV(m *Model, args []string) error {
+	values, err := parseNumbers(args, "<degrees>")
+	if err != nil {
+		return err
+	}
+	return m.setFOV(values[0])
+}
+
+// setFOV zooms to a field of view within the configured limits
+func (m *Model) setFOV(fov float64) error {
+	if fov < m.config.Controls.MinFOV || fov > 120 {
+		return fmt.Errorf("field of view must be between %g° and 120°", m.config.Controls.MinFOV)
+	}
+	m.fov = fov
+	return nil
+}
+
+// cmdGoto centers on coordinates, or on the best search match for a name
+func cmdGoto(m *Model, args []string) error {
+	if len(args) == 0 {
+		return errors.New("expected an object name or coordinates")
+	}
+	target := strings.Join(args, " ")
+
+	if _, err := astro.ParseCoordinates(target); err == nil {
+		m.applyGoto(target)
+		return nil
+	}
+
+	results := ui.Search(target, m.searchItems(), 1)
+	if len(results) == 0 {
+		return fmt.Errorf("no object or coordinates match %q", target)
+	}
+	m.selectSearchResult(results[0])
+	m.CenterOnSelected()
+	return nil
+}
+
+// cmdTime sets the simulated time and pauses, like the time prompt
+func cmdTime(m *Model, args []string) error {
+	if err := m.setTimeInput(strings.Join(args, " ")); err != nil {
+		return err
+	}
+	m.updatePositions()
+	return nil
+}
+
+// cmdSpeed sets the time multiplier, e.g. "60x", "-60x" to run backward, or
+// "sidereal" for one sidereal day per second
+func cmdSpeed(m *Model, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected a rate such as 60x")
+	}
+	arg := strings.ToLower(args[0])
+	switch arg {
+	case "sidereal":
+		m.setRate(siderealDayRate)
+		return nil
+	case "-sidereal":
+		m.setRate(-siderealDayRate)
+		return nil
+	}
+	rate, err := strconv.ParseFloat(strings.TrimSuffix(arg, "x"), 64)
+	if err != nil || rate == 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return fmt.Errorf("%q is not a rate; use e.g. 60x, -10x or sidereal", args[0])
+	}
+	m.setRate(rate)
+	return nil
+}
+
+func cmdPause(m *Model, _ []string) error {
+	m.setPaused(true)
+	return nil
+}
+
+func cmdPlay(m *Model, _ []string) error {
+	m.setPaused(false)
+	return nil
+}
+
+// cmdZone picks the zone times are shown and typed in
+func cmdZone(m *Model, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected site, local or utc")
+	}
+	for i, name := range zoneModeNames {
+		if strings.EqualFold(args[0], name) {
+			m.setZoneMode(zoneMode(i))
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown zone %q (site, local or utc)", args[0])
+}
+
+// cmdLoc moves the observer, keeping the configured extinction coefficient
+func cmdLoc(m *Model, args []string) error {
+	site, err := manualSite(args)
+	if err != nil {
+		return err
+	}
+	m.setSite(site)
+	m.statusMessage = "Observing " + nearestPlace(site.Latitude, site.Longitude)
+	return nil
+}
+
+// cmdSite moves the observer to a named site from the config, or else to
+// a city from the gazetteer
+func cmdSite(m *Model, args []string) error {
+	if len(args) == 0 {
+		return errors.New("expected a site or place name")
+	}
+	site, err := FindSite(m.config, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	m.setSite(site)
+	return nil
+}
+
+func completeSites(m *Model, args []string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+	var names []string
+	for _, site := range m.config.AllSites() {
+		names = append(names, site.Name)
+	}
+	return names
+}
+
+// formatLatLon names a location by its coordinates
+func formatLatLon(lat, lon float64) string {
+	ns, ew := "N", "E"
+	if lat < 0 {
+		ns = "S"
+	}
+	if lon < 0 {
+		ew = "W"
+	}
+	return fmt.Sprintf("%.2f°%s %.2f°%s", math.Abs(lat), ns, math.Abs(lon), ew)
+}
+
+// cmdSet turns a display option on, off, or toggles it
+func cmdSet(m *Model, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New("expected set <option> [on|off|toggle]")
+	}
+	option, ok := displayOptions[strings.ToLower(args[0])]
+	if !ok {
+		return fmt.Errorf("unknown option %q; options: %s", args[0], strings.Join(completeSet(m, nil), ", "))
+	}
+
+	value := option(m)
+	state := "toggle"
+	if len(args) == 2 {
+		state = strings.ToLower(args[1])
+	}
+	switch state {
+	case "on", "true", "yes", "1":
+		*value = true
+	case "off", "false", "no", "0":
+		*value = false
+	case "toggle":
+		*value = !*value
+	default:
+		return fmt.Errorf("expected on, off or toggle, not %q", args[1])
+	}
+	return nil
+}
+
+func cmdTheme(m *Model, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected a theme name")
+	}
+	th, err := theme.ByName(args[0])
+	if err != nil {
+		return err
+	}
+	theme.Set(th)
+	if !th.ShowImages {
+		m.imageViewMode = false
+	}
+	return nil
+}
+
+// cmdHelp lists the commands in the status line, or shows one's usage
+func cmdHelp(m *Model, args []string) error {
+	if len(args) > 0 {
+		cmd, err := lookupCommand(args[0])
+		if err != nil {
+			return err
+		}
+		m.statusMessage = "Usage: :" + cmd.usage
+		return nil
+	}
+
+	m.statusMessage = "Commands: " + strings.Join(completeCommands(m, nil), " ") + " (Tab completes)"
+	return nil
+}
+
+// handleCommandKey edits, completes and runs the command line
+func (m *Model) handleCommandKey(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, m.keys.CommandCancel):
+		m.cmdMode = false
+	case key.Matches(msg, m.keys.CommandRun):
+		m.cmdMode = false
+		line := strings.TrimSpace(m.cmdInput)
+		if line == "" {
+			return
+		}
+		if len(m.cmdHistory) == 0 || m.cmdHistory[len(m.cmdHistory)-1] != line {
+			m.cmdHistory = append(m.cmdHistory, line)
+		}
+		if err := m.RunCommand(line); err != nil {
+			m.statusMessage = err.Error()
+		}
+	case key.Matches(msg, m.keys.CommandComplete):
+		m.cmdInput, m.cmdCandidates = m.completeCommand(m.cmdInput)
+	case key.Matches(msg, m.keys.CommandPrevious):
+		if m.cmdHistoryPos > 0 {
+			m.cmdHistoryPos--
+			m.cmdInput = m.cmdHistory[m.cmdHistoryPos]
+		}
+	case key.Matches(msg, m.keys.CommandNext):
+		if m.cmdHistoryPos < len(m.cmdHistory)-1 {
+			m.cmdHistoryPos++
+			m.cmdInput = m.cmdHistory[m.cmdHistoryPos]
+		} else {
+			m.cmdHistoryPos = len(m.cmdHistory)
+			m.cmdInput = ""
+		}
+	case msg.String() == "backspace":
+		if m.cmdInput == "" {
+			m.cmdMode = false
+			return
+		}
+		_, size := utf8.DecodeLastRuneInString(m.cmdInput)
+		m.cmdInput = m.cmdInput[:len(m.cmdInput)-size]
+		m.cmdCandidates = nil
+	default:
+		if len(msg.Runes) > 0 {
+			m.cmdInput += string(msg.Runes)
+			m.cmdCandidates = nil
+		}
+	}
+}
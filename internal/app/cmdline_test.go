@@ -0,0 +1,139 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/craigderington/skyterm/internal/config"
+)
+
+func TestRunCommand(t *testing.T) {
+	tests := []struct {
+		line    string
+		wantErr string            // Part of the error; empty for success
+		check   func(*Model) bool // State after a successful run
+	}{
+		{line: "", check: func(*Model) bool { return true }},
+		{line: "# a comment", check: func(*Model) bool { return true }},
+		{line: ":mag 6.5", check: func(m *Model) bool { return m.magnitudeLimit == 6.5 }},
+		{line: "mag 13", wantErr: "out of range"},
+		{line: "mag -2.5", wantErr: "out of range"},
+		{line: "mag bright", wantErr: `"bright" is not a number`},
+		{line: "mag", wantErr: "expected <limit>"},
+		{line: "fov 10", check: func(m *Model) bool { return m.fov == 10 }},
+		{line: "fov 0.01", wantErr: "between 0.1° and 120°"},
+		{line: "fov 121", wantErr: "between 0.1° and 120°"},
+		{line: "set grid on", check: func(m *Model) bool { return m.showGrid }},
+		{line: "set grid off", check: func(m *Model) bool { return !m.showGrid }},
+		{line: "set grid", check: func(m *Model) bool { return m.showGrid }}, // Off by default
+		{line: "set GRID toggle", check: func(m *Model) bool { return m.showGrid }},
+		{line: "set starlabels no", check: func(m *Model) bool { return !m.showStarLabels }},
+		{line: "set grid maybe", wantErr: `expected on, off or toggle, not "maybe"`},
+		{line: "set sparkles on", wantErr: `unknown option "sparkles"`},
+		{line: "set", wantErr: "expected set <option>"},
+		{line: "goto 05h35m17s -05d23m28s", check: func(m *Model) bool { return m.marker != nil && m.gotoInput == "" }},
+		{line: "speed 60x", check: func(m *Model) bool { return m.timeMultiplier == 60 }},
+		{line: "speed -10", check: func(m *Model) bool { return m.timeMultiplier == -10 }},
+		{line: "speed sidereal", check: func(m *Model) bool { return m.timeMultiplier == siderealDayRate }},
+		{line: "speed fast", wantErr: `"fast" is not a rate`},
+		{line: "speed 0x", wantErr: `"0x" is not a rate`},
+		{line: "speed NaNx", wantErr: "is not a rate"},
+		{line: "speed 1x 2x", wantErr: "expected a rate"},
+		{line: "frobnicate", wantErr: `unknown command "frobnicate"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			m := newTestModel(t)
+			err := m.RunCommand(tt.line)
+			switch {
+			case tt.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case !tt.check(m):
+				t.Error("command did not take effect")
+			}
+		})
+	}
+}
+
+func TestLookupCommand(t *testing.T) {
+	tests := []struct {
+		name, want, wantErr string
+	}{
+		{name: "goto", want: "goto"},
+		{name: "GO", want: "goto"},
+		{name: "sp", want: "speed"},
+		{name: "set", want: "set"}, // An exact name wins over longer matches
+		{name: "t", wantErr: `ambiguous command "t": time, theme`},
+		{name: "s", wantErr: `ambiguous command "s": speed, site, set`},
+		{name: "x", wantErr: `unknown command "x"`},
+	}
+
+	for _, tt := range tests {
+		cmd, err := lookupCommand(tt.name)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("lookupCommand(%q) error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || cmd.name != tt.want {
+			t.Errorf("lookupCommand(%q) = %q, %v; want %q", tt.name, cmd.name, err, tt.want)
+		}
+	}
+}
+
+func TestCompleteCommand(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Sites = []config.SiteConfig{{Name: "Québec"}, {Name: "Quèbec-Nord"}}
+	m := newTestModelWith(t, cfg, Options{})
+	tests := []struct {
+		line, want string
+		candidates []string
+	}{
+		{line: "go", want: "goto "},
+		{line: "the", want: "theme "},
+		{line: "set pl", want: "set planet", candidates: []string{"planets", "planetlabels"}},
+		{line: "set d", want: "set d", candidates: []string{"deepsky", "daylight"}},
+		{line: "zone U", want: "zone utc "},
+		{line: "p", want: "p", candidates: []string{"pause", "play"}},
+		{line: "set zz", want: "set zz"},
+		{line: "site Qu", want: "site Qu", candidates: []string{"Québec", "Quèbec-Nord"}}, // é and è share a first byte
+	}
+
+	for _, tt := range tests {
+		got, candidates := m.completeCommand(tt.line)
+		if got != tt.want {
+			t.Errorf("completeCommand(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+		slices.Sort(candidates)
+		slices.Sort(tt.candidates)
+		if !slices.Equal(candidates, tt.candidates) {
+			t.Errorf("completeCommand(%q) candidates = %v, want %v", tt.line, candidates, tt.candidates)
+		}
+	}
+}
+
+func TestRunScriptReportsLine(t *testing.T) {
+	m := newTestModel(t)
+	path := filepath.Join(t.TempDir(), "startup")
+	script := "mag 6\n\n# Wide field for the Milky Way\nfov 500\nmag 3\n"
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := m.RunScript(path)
+	if err == nil || !strings.HasPrefix(err.Error(), path+":4: field of view") {
+		t.Fatalf("error = %v, want it to name %s:4", err, path)
+	}
+	if m.magnitudeLimit != 6 {
+		t.Errorf("magnitude limit = %g; lines before the failure should run, later ones not", m.magnitudeLimit)
+	}
+}
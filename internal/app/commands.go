@@ -1,6 +1,7 @@
 package app
 
 import (
+	"os"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -10,9 +11,9 @@ import (
 // TickMsg is sent on every tick to update the time
 type TickMsg time.Time
 
-// tickCmd returns a command that sends a tick message
-func tickCmd() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+// tickCmd returns a command that sends a tick message after interval
+func tickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return TickMsg(t)
 	})
 }
@@ -78,3 +79,17 @@ func fetchImageCmd(objectName string) tea.Cmd {
 		}
 	}
 }
+
+// SnapshotSavedMsg is sent when a text snapshot has been written
+type SnapshotSavedMsg struct {
+	Path  string
+	Error error
+}
+
+// saveSnapshotCmd writes a plain-text snapshot of the sky view to path
+func saveSnapshotCmd(path, text string) tea.Cmd {
+	return func() tea.Msg {
+		err := os.WriteFile(path, []byte(text), 0o644)
+		return SnapshotSavedMsg{Path: path, Error: err}
+	}
+}
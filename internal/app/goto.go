@@ -0,0 +1,40 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/catalog"
+)
+
+// markerName labels the go-to marker on the sky
+const markerName = "Target"
+
+// applyGoto parses typed coordinates, places the marker there, selects it
+// and centers the view. An empty input removes the marker. It reports
+// false, with gotoError set, when the input can't be read.
+func (m *Model) applyGoto(input string) bool {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		m.marker = nil
+		m.refreshSelection()
+		return true
+	}
+
+	coords, err := astro.ParseCoordinates(input)
+	if err != nil {
+		m.gotoError = err.Error()
+		return false
+	}
+
+	m.marker = catalog.NewMarker(markerName, coords, m.currentTime)
+	m.marker.UpdatePosition(m.observer, m.currentTime)
+
+	m.selectedObject = &SelectedObject{
+		Type:   "marker",
+		Name:   m.marker.Name,
+		Marker: m.marker,
+	}
+	m.CenterOnSelected()
+	return true
+}
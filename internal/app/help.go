@@ -0,0 +1,65 @@
+package app
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/craigderington/skyterm/internal/ui"
+)
+
+// helpView returns the help screen state
+func (m *Model) helpView() ui.HelpView {
+	return ui.HelpView{
+		Sections:  m.keys.helpSections(),
+		Query:     m.helpQuery,
+		Searching: m.helpSearching,
+		Scroll:    m.helpScroll,
+		Hints: []ui.HelpEntry{
+			{Keys: displayKey(m.keys.HelpDown.Keys()[0]) + "/" + displayKey(m.keys.HelpUp.Keys()[0]), Desc: "scroll"},
+			{Keys: m.keys.HelpSearch.Help().Key, Desc: "filter"},
+			{Keys: m.keys.CloseHelp.Help().Key, Desc: "close"},
+		},
+	}
+}
+
+// handleHelpKey scrolls, filters or closes the help screen
+func (m *Model) handleHelpKey(msg tea.KeyMsg) {
+	// Typing a filter takes every key until Enter or Esc
+	if m.helpSearching {
+		switch msg.String() {
+		case "enter":
+			m.helpSearching = false
+		case "esc":
+			m.helpSearching = false
+			m.helpQuery = ""
+		case "backspace":
+			if len(m.helpQuery) > 0 {
+				m.helpQuery = m.helpQuery[:len(m.helpQuery)-1]
+			}
+		default:
+			m.helpQuery += string(msg.Runes)
+		}
+		m.helpScroll = 0
+		return
+	}
+
+	height := m.height + 2
+	page := ui.HelpPageHeight(height)
+
+	switch {
+	case key.Matches(msg, m.keys.CloseHelp):
+		m.showHelp = false
+	case key.Matches(msg, m.keys.HelpSearch):
+		m.helpSearching = true
+		m.helpQuery = ""
+	case key.Matches(msg, m.keys.HelpDown):
+		m.helpScroll++
+	case key.Matches(msg, m.keys.HelpUp):
+		m.helpScroll--
+	case key.Matches(msg, m.keys.HelpPageDown):
+		m.helpScroll += page
+	case key.Matches(msg, m.keys.HelpPageUp):
+		m.helpScroll -= page
+	}
+
+	m.helpScroll = max(0, min(m.helpScroll, ui.HelpMaxScroll(m.helpView(), height)))
+}
@@ -1,6 +1,14 @@
 package app
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/craigderington/skyterm/internal/config"
+	"github.com/craigderington/skyterm/internal/ui"
+)
 
 type keyMap struct {
 	// Navigation
@@ -34,6 +42,14 @@ type keyMap struct {
 	DeepSky        key.Binding
 	StarLabels     key.Binding
 	Magnitude      key.Binding
+	Daylight       key.Binding
+	Theme          key.Binding
+	Equipment      key.Binding
+	RotateFrame    key.Binding
+	Trails         key.Binding
+	StarTrails     key.Binding
+	Snapshot       key.Binding
+	Minimap        key.Binding
 
 	// Selection and interaction
 	Select     key.Binding
@@ -42,6 +58,9 @@ type keyMap struct {
 	Center     key.Binding
 	Follow     key.Binding
 	Search     key.Binding
+	Goto       key.Binding
+	Command    key.Binding
+	Sites      key.Binding
 
 	// Time controls
 	PauseResume    key.Binding
@@ -51,11 +70,52 @@ type keyMap struct {
 	FastStepForward key.Binding
 	JumpToNow      key.Binding
 	SetTime        key.Binding
+	Faster         key.Binding
+	Slower         key.Binding
+	Reverse        key.Binding
+	Zone           key.Binding
 
 	// General
-	Help      key.Binding
-	Quit      key.Binding
-	CloseHelp key.Binding
+	Help key.Binding
+	Quit key.Binding
+
+	// Help screen
+	HelpUp       key.Binding
+	HelpDown     key.Binding
+	HelpPageUp   key.Binding
+	HelpPageDown key.Binding
+	HelpSearch   key.Binding
+	CloseHelp    key.Binding
+
+	// Search box
+	SearchUp     key.Binding
+	SearchDown   key.Binding
+	SearchSelect key.Binding
+	SearchCancel key.Binding
+
+	// Time input
+	TimeSet    key.Binding
+	TimeCancel key.Binding
+
+	// Go-to entry
+	GotoSelect key.Binding
+	GotoCancel key.Binding
+
+	// Command line
+	CommandRun      key.Binding
+	CommandComplete key.Binding
+	CommandPrevious key.Binding
+	CommandNext     key.Binding
+	CommandCancel   key.Binding
+
+	// Site picker
+	SiteUp     key.Binding
+	SiteDown   key.Binding
+	SiteSelect key.Binding
+	SiteCancel key.Binding
+
+	// Image viewer
+	CloseImage key.Binding
 }
 
 func newKeyMap() keyMap {
@@ -63,175 +123,609 @@ func newKeyMap() keyMap {
 		// Navigation
 		Up: key.NewBinding(
 			key.WithKeys("up", "k"),
-			key.WithHelp("↑/k", "pan up"),
+			key.WithHelp("↑/k", "Pan up"),
 		),
 		Down: key.NewBinding(
 			key.WithKeys("down", "j"),
-			key.WithHelp("↓/j", "pan down"),
+			key.WithHelp("↓/j", "Pan down"),
 		),
 		Left: key.NewBinding(
 			key.WithKeys("left", "h"),
-			key.WithHelp("←/h", "pan left"),
+			key.WithHelp("←/h", "Pan left"),
 		),
 		Right: key.NewBinding(
 			key.WithKeys("right", "l"),
-			key.WithHelp("→/l", "pan right"),
+			key.WithHelp("→/l", "Pan right"),
 		),
 		FastUp: key.NewBinding(
 			key.WithKeys("K"),
-			key.WithHelp("K", "fast pan up"),
+			key.WithHelp("K", "Fast pan up"),
 		),
 		FastDown: key.NewBinding(
 			key.WithKeys("J"),
-			key.WithHelp("J", "fast pan down"),
+			key.WithHelp("J", "Fast pan down"),
 		),
 		FastLeft: key.NewBinding(
 			key.WithKeys("H"),
-			key.WithHelp("H", "fast pan left"),
+			key.WithHelp("H", "Fast pan left"),
 		),
 		FastRight: key.NewBinding(
 			key.WithKeys("L"),
-			key.WithHelp("L", "fast pan right"),
+			key.WithHelp("L", "Fast pan right"),
 		),
 
 		// Zoom
 		ZoomIn: key.NewBinding(
 			key.WithKeys("+", "="),
-			key.WithHelp("+", "zoom in"),
+			key.WithHelp("+", "Zoom in"),
 		),
 		ZoomOut: key.NewBinding(
 			key.WithKeys("-"),
-			key.WithHelp("-", "zoom out"),
+			key.WithHelp("-", "Zoom out"),
 		),
 		Reset: key.NewBinding(
 			key.WithKeys("0"),
-			key.WithHelp("0", "reset view"),
+			key.WithHelp("0", "Reset view"),
 		),
 
 		// Cardinals
 		North: key.NewBinding(
 			key.WithKeys("n"),
-			key.WithHelp("n", "north"),
+			key.WithHelp("n", "Face north"),
 		),
 		South: key.NewBinding(
 			key.WithKeys("s"),
-			key.WithHelp("s", "south"),
+			key.WithHelp("s", "Face south"),
 		),
 		East: key.NewBinding(
 			key.WithKeys("e"),
-			key.WithHelp("e", "east"),
+			key.WithHelp("e", "Face east"),
 		),
 		West: key.NewBinding(
 			key.WithKeys("w"),
-			key.WithHelp("w", "west"),
+			key.WithHelp("w", "Face west"),
 		),
 		Zenith: key.NewBinding(
 			key.WithKeys("z"),
-			key.WithHelp("z", "zenith"),
+			key.WithHelp("z", "Look to zenith (straight up)"),
 		),
 
 		// Display
 		Grid: key.NewBinding(
 			key.WithKeys("g"),
-			key.WithHelp("g", "toggle grid"),
+			key.WithHelp("g", "Toggle coordinate grid"),
 		),
 		Constellations: key.NewBinding(
 			key.WithKeys("C"),
-			key.WithHelp("C", "toggle constellations"),
+			key.WithHelp("C", "Toggle constellation lines"),
 		),
 		Names: key.NewBinding(
 			key.WithKeys("N"),
-			key.WithHelp("N", "toggle names"),
+			key.WithHelp("N", "Toggle constellation names"),
 		),
 		Planets: key.NewBinding(
 			key.WithKeys("p"),
-			key.WithHelp("p", "toggle planets"),
+			key.WithHelp("p", "Toggle planets (Sun, Moon, planets)"),
 		),
 		PlanetLabels: key.NewBinding(
 			key.WithKeys("P"),
-			key.WithHelp("P", "toggle planet labels"),
+			key.WithHelp("P", "Toggle planet labels"),
 		),
 		DeepSky: key.NewBinding(
 			key.WithKeys("d"),
-			key.WithHelp("d", "toggle deep sky objects"),
+			key.WithHelp("d", "Toggle deep sky objects (Messier)"),
 		),
 		StarLabels: key.NewBinding(
 			key.WithKeys("S"),
-			key.WithHelp("S", "toggle star labels"),
+			key.WithHelp("S", "Toggle star labels (bright stars)"),
 		),
 		Magnitude: key.NewBinding(
 			key.WithKeys("m"),
-			key.WithHelp("m", "cycle magnitude"),
+			key.WithHelp("m", "Cycle magnitude limit (3/4/5/6)"),
+		),
+		Daylight: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "Toggle daylight and moonlight"),
+		),
+		Theme: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "Cycle color theme (night = red)"),
+		),
+		Equipment: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "Cycle equipment overlay"),
+		),
+		RotateFrame: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "Rotate camera frame"),
+		),
+		Trails: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "Cycle trails (selected/all planets)"),
+		),
+		StarTrails: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "Toggle star trail exposure"),
+		),
+		Snapshot: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "Save text snapshot"),
+		),
+		Minimap: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "Toggle all-sky minimap"),
 		),
 
 		// Selection and interaction
 		Select: key.NewBinding(
 			key.WithKeys("enter"),
-			key.WithHelp("enter", "select object"),
+			key.WithHelp("enter", "Select nearest object to center"),
 		),
 		Info: key.NewBinding(
 			key.WithKeys("i"),
-			key.WithHelp("i", "toggle info"),
+			key.WithHelp("i", "Toggle info panel for selected"),
 		),
 		ViewImage: key.NewBinding(
 			key.WithKeys("v"),
-			key.WithHelp("v", "view image"),
+			key.WithHelp("v", "View image fullscreen"),
 		),
 		Center: key.NewBinding(
 			key.WithKeys("c"),
-			key.WithHelp("c", "center on selected"),
+			key.WithHelp("c", "Center view on selected object"),
 		),
 		Follow: key.NewBinding(
 			key.WithKeys("f"),
-			key.WithHelp("f", "follow selected"),
+			key.WithHelp("f", "Follow selected object (lock view)"),
 		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
-			key.WithHelp("/", "search objects"),
+			key.WithHelp("/", "Search by name, M or NGC number"),
+		),
+		Goto: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("G", "Go to RA/Dec or Alt/Az coordinates"),
+		),
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "Command line (:help lists commands)"),
+		),
+		Sites: key.NewBinding(
+			key.WithKeys("@"),
+			key.WithHelp("@", "Switch observing site"),
 		),
 
 		// Time controls
 		PauseResume: key.NewBinding(
 			key.WithKeys(" "),
-			key.WithHelp("space", "pause/resume time"),
+			key.WithHelp("space", "Pause/resume time flow"),
 		),
 		StepBackward: key.NewBinding(
 			key.WithKeys("["),
-			key.WithHelp("[", "step time backward"),
+			key.WithHelp("[", "Step time backward"),
 		),
 		StepForward: key.NewBinding(
 			key.WithKeys("]"),
-			key.WithHelp("]", "step time forward"),
+			key.WithHelp("]", "Step time forward"),
 		),
 		FastStepBack: key.NewBinding(
 			key.WithKeys("{"),
-			key.WithHelp("{", "fast step backward"),
+			key.WithHelp("{", "Fast step time backward"),
 		),
 		FastStepForward: key.NewBinding(
 			key.WithKeys("}"),
-			key.WithHelp("}", "fast step forward"),
+			key.WithHelp("}", "Fast step time forward"),
 		),
 		JumpToNow: key.NewBinding(
 			key.WithKeys("T"),
-			key.WithHelp("T", "jump to current time"),
+			key.WithHelp("T", "Jump to now at real time"),
 		),
 		SetTime: key.NewBinding(
 			key.WithKeys("t"),
-			key.WithHelp("t", "set time"),
+			key.WithHelp("t", "Set custom time"),
+		),
+		Faster: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "Faster (1×/10×/60×/600×/1 sidereal day/s)"),
+		),
+		Slower: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "Slower"),
+		),
+		Reverse: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "Reverse time direction"),
+		),
+		Zone: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "Cycle time zone (site/local/UTC)"),
 		),
 
 		// General
 		Help: key.NewBinding(
 			key.WithKeys("?"),
-			key.WithHelp("?", "help"),
+			key.WithHelp("?", "Toggle this help screen"),
 		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
-			key.WithHelp("q", "quit"),
+			key.WithHelp("q", "Quit application"),
+		),
+
+		// Help screen
+		HelpUp: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/↑", "Scroll up"),
+		),
+		HelpDown: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/↓", "Scroll down"),
+		),
+		HelpPageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+u"),
+			key.WithHelp("pgup/ctrl+u", "Page up"),
+		),
+		HelpPageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+d", " "),
+			key.WithHelp("pgdown/ctrl+d/space", "Page down"),
+		),
+		HelpSearch: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "Filter help"),
 		),
 		CloseHelp: key.NewBinding(
-			key.WithKeys("esc", "?"),
-			key.WithHelp("esc/?", "close help"),
+			key.WithKeys("esc", "?", "q"),
+			key.WithHelp("esc/?/q", "Close help"),
+		),
+
+		// Search box
+		SearchUp: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "Previous"),
+		),
+		SearchDown: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "Next"),
+		),
+		SearchSelect: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Select"),
+		),
+		SearchCancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Cancel"),
+		),
+
+		// Time input
+		TimeSet: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Set time"),
+		),
+		TimeCancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Cancel"),
+		),
+
+		// Go-to entry
+		GotoSelect: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Go, empty clears marker"),
+		),
+		GotoCancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Cancel"),
+		),
+
+		// Command line
+		CommandRun: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Run"),
+		),
+		CommandComplete: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "Complete"),
+		),
+		CommandPrevious: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "Previous command"),
+		),
+		CommandNext: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "Next command"),
+		),
+		CommandCancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Cancel"),
+		),
+
+		// Site picker
+		SiteUp: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "Previous"),
+		),
+		SiteDown: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "Next"),
+		),
+		SiteSelect: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "Switch site"),
+		),
+		SiteCancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "Cancel"),
 		),
+
+		// Image viewer
+		CloseImage: key.NewBinding(
+			key.WithKeys("esc", "v", "q"),
+			key.WithHelp("esc/v/q", "Close image"),
+		),
+	}
+}
+
+// keyAction names a binding for the keys: section of config.yaml and files
+// it under a help category
+type keyAction struct {
+	name     string
+	category string
+	scope    string // Where the binding applies; empty for the sky view
+	binding  *key.Binding
+}
+
+// helpCategories lists the help sections in display order
+var helpCategories = []string{
+	"Navigation",
+	"Cardinal Directions",
+	"Display Toggles",
+	"Object Interaction",
+	"Time Controls",
+	"General",
+	"Help Screen",
+	"Search",
+	"Time Input",
+	"Go To",
+	"Command Line",
+	"Site Picker",
+	"Image Viewer",
+}
+
+// actions returns every binding with its action name, in help order
+func (k *keyMap) actions() []keyAction {
+	return []keyAction{
+		{"pan_up", "Navigation", "", &k.Up},
+		{"pan_down", "Navigation", "", &k.Down},
+		{"pan_left", "Navigation", "", &k.Left},
+		{"pan_right", "Navigation", "", &k.Right},
+		{"fast_pan_up", "Navigation", "", &k.FastUp},
+		{"fast_pan_down", "Navigation", "", &k.FastDown},
+		{"fast_pan_left", "Navigation", "", &k.FastLeft},
+		{"fast_pan_right", "Navigation", "", &k.FastRight},
+		{"zoom_in", "Navigation", "", &k.ZoomIn},
+		{"zoom_out", "Navigation", "", &k.ZoomOut},
+		{"reset_view", "Navigation", "", &k.Reset},
+
+		{"north", "Cardinal Directions", "", &k.North},
+		{"south", "Cardinal Directions", "", &k.South},
+		{"east", "Cardinal Directions", "", &k.East},
+		{"west", "Cardinal Directions", "", &k.West},
+		{"zenith", "Cardinal Directions", "", &k.Zenith},
+
+		{"grid", "Display Toggles", "", &k.Grid},
+		{"constellation_lines", "Display Toggles", "", &k.Constellations},
+		{"constellation_names", "Display Toggles", "", &k.Names},
+		{"planets", "Display Toggles", "", &k.Planets},
+		{"planet_labels", "Display Toggles", "", &k.PlanetLabels},
+		{"deep_sky", "Display Toggles", "", &k.DeepSky},
+		{"star_labels", "Display Toggles", "", &k.StarLabels},
+		{"magnitude", "Display Toggles", "", &k.Magnitude},
+		{"daylight", "Display Toggles", "", &k.Daylight},
+		{"theme", "Display Toggles", "", &k.Theme},
+		{"trails", "Display Toggles", "", &k.Trails},
+		{"minimap", "Display Toggles", "", &k.Minimap},
+		{"star_trails", "Display Toggles", "", &k.StarTrails},
+		{"snapshot", "Display Toggles", "", &k.Snapshot},
+
+		{"select", "Object Interaction", "", &k.Select},
+		{"info", "Object Interaction", "", &k.Info},
+		{"view_image", "Object Interaction", "", &k.ViewImage},
+		{"center", "Object Interaction", "", &k.Center},
+		{"follow", "Object Interaction", "", &k.Follow},
+		{"equipment", "Object Interaction", "", &k.Equipment},
+		{"rotate_frame", "Object Interaction", "", &k.RotateFrame},
+		{"search", "Object Interaction", "", &k.Search},
+		{"goto", "Object Interaction", "", &k.Goto},
+		{"sites", "Object Interaction", "", &k.Sites},
+
+		{"pause", "Time Controls", "", &k.PauseResume},
+		{"step_back", "Time Controls", "", &k.StepBackward},
+		{"step_forward", "Time Controls", "", &k.StepForward},
+		{"fast_step_back", "Time Controls", "", &k.FastStepBack},
+		{"fast_step_forward", "Time Controls", "", &k.FastStepForward},
+		{"now", "Time Controls", "", &k.JumpToNow},
+		{"set_time", "Time Controls", "", &k.SetTime},
+		{"faster", "Time Controls", "", &k.Faster},
+		{"slower", "Time Controls", "", &k.Slower},
+		{"reverse", "Time Controls", "", &k.Reverse},
+		{"zone", "Time Controls", "", &k.Zone},
+
+		{"command", "General", "", &k.Command},
+		{"help", "General", "", &k.Help},
+		{"quit", "General", "", &k.Quit},
+
+		{"help_up", "Help Screen", "help", &k.HelpUp},
+		{"help_down", "Help Screen", "help", &k.HelpDown},
+		{"help_page_up", "Help Screen", "help", &k.HelpPageUp},
+		{"help_page_down", "Help Screen", "help", &k.HelpPageDown},
+		{"help_search", "Help Screen", "help", &k.HelpSearch},
+		{"close_help", "Help Screen", "help", &k.CloseHelp},
+
+		{"search_up", "Search", "search", &k.SearchUp},
+		{"search_down", "Search", "search", &k.SearchDown},
+		{"search_select", "Search", "search", &k.SearchSelect},
+		{"search_cancel", "Search", "search", &k.SearchCancel},
+
+		{"time_set", "Time Input", "time", &k.TimeSet},
+		{"time_cancel", "Time Input", "time", &k.TimeCancel},
+
+		{"goto_select", "Go To", "goto", &k.GotoSelect},
+		{"goto_cancel", "Go To", "goto", &k.GotoCancel},
+
+		{"command_run", "Command Line", "command", &k.CommandRun},
+		{"command_complete", "Command Line", "command", &k.CommandComplete},
+		{"command_previous", "Command Line", "command", &k.CommandPrevious},
+		{"command_next", "Command Line", "command", &k.CommandNext},
+		{"command_cancel", "Command Line", "command", &k.CommandCancel},
+
+		{"site_up", "Site Picker", "sites", &k.SiteUp},
+		{"site_down", "Site Picker", "sites", &k.SiteDown},
+		{"site_select", "Site Picker", "sites", &k.SiteSelect},
+		{"site_cancel", "Site Picker", "sites", &k.SiteCancel},
+
+		{"close_image", "Image Viewer", "image", &k.CloseImage},
+	}
+}
+
+// helpSections groups the effective bindings for the help screen
+func (k *keyMap) helpSections() []ui.HelpSection {
+	sections := make([]ui.HelpSection, len(helpCategories))
+	for i, category := range helpCategories {
+		sections[i] = ui.HelpSection{Title: category, Entries: k.contextHelp(category)}
+
+		// The mouse isn't rebindable but belongs with selection
+		if category == "Object Interaction" {
+			sections[i].Entries = append(sections[i].Entries, ui.HelpEntry{Keys: "Mouse", Desc: "Click select, drag pan, wheel zoom"})
+		}
+	}
+	return sections
+}
+
+// contextHelp returns the bindings of one help category, for the key hints
+// shown inside a mode
+func (k *keyMap) contextHelp(category string) []ui.HelpEntry {
+	var entries []ui.HelpEntry
+	for _, a := range k.actions() {
+		if a.category == category {
+			help := a.binding.Help()
+			entries = append(entries, ui.HelpEntry{Keys: help.Key, Desc: help.Desc})
+		}
+	}
+	return entries
+}
+
+// loadKeyMap applies the keys: overrides from the config to the default
+// keymap. Unknown actions, empty key lists and keys bound to two actions in
+// the same place are all reported; if there are any, the defaults are used.
+// ctrl+c quits whatever quit is bound to.
+func loadKeyMap(overrides map[string]config.KeyList) (keyMap, error) {
+	k := newKeyMap()
+	if len(overrides) == 0 {
+		return k, nil
+	}
+
+	actions := k.actions()
+	var problems []string
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		i := slices.IndexFunc(actions, func(a keyAction) bool { return a.name == name })
+		if i < 0 {
+			problems = append(problems, fmt.Sprintf("unknown action %q", name))
+			continue
+		}
+
+		var keys []string
+		for _, s := range overrides[name] {
+			if s = normalizeKey(s); s != "" {
+				keys = append(keys, s)
+			}
+		}
+		if len(keys) == 0 {
+			problems = append(problems, fmt.Sprintf("%s has no keys", name))
+			continue
+		}
+
+		b := actions[i].binding
+		b.SetHelp(helpKeys(keys), b.Help().Desc)
+		if name == "quit" && !slices.Contains(keys, "ctrl+c") {
+			keys = append(keys, "ctrl+c") // Never leave the user without a way out
+		}
+		b.SetKeys(keys...)
+	}
+
+	problems = append(problems, conflicts(actions)...)
+	if len(problems) > 0 {
+		return newKeyMap(), fmt.Errorf("keys: %s; using default keys", strings.Join(problems, "; "))
+	}
+	return k, nil
+}
+
+// conflicts describes every key bound to more than one action in a scope
+func conflicts(actions []keyAction) []string {
+	type scopedKey struct{ scope, key string }
+	bound := make(map[scopedKey][]string)
+	var order []scopedKey
+
+	for _, a := range actions {
+		for _, s := range a.binding.Keys() {
+			sk := scopedKey{a.scope, s}
+			if len(bound[sk]) == 0 {
+				order = append(order, sk)
+			}
+			bound[sk] = append(bound[sk], a.name)
+		}
+	}
+
+	var problems []string
+	for _, sk := range order {
+		if names := bound[sk]; len(names) > 1 {
+			problems = append(problems, fmt.Sprintf("%q is bound to %s", displayKey(sk.key), strings.Join(names, " and ")))
+		}
+	}
+	return problems
+}
+
+// normalizeKey converts a key as written in the config to the name bubbletea
+// reports. Named keys are lowercase; single characters keep their case.
+func normalizeKey(s string) string {
+	if s == " " {
+		return s
+	}
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.EqualFold(s, "space"):
+		return " "
+	case len([]rune(s)) > 1:
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// displayKey returns a key's name as shown in help
+func displayKey(s string) string {
+	switch s {
+	case " ":
+		return "space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	return s
+}
+
+// helpKeys joins the display names of a binding's keys
+func helpKeys(keys []string) string {
+	names := make([]string, len(keys))
+	for i, s := range keys {
+		names[i] = displayKey(s)
 	}
+	return strings.Join(names, "/")
 }
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/craigderington/skyterm/internal/config"
+)
+
+func TestLoadKeyMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]config.KeyList
+		wantErr   string // Part of the error; empty for success
+		check     func(keyMap) bool
+	}{
+		{
+			name:  "defaults",
+			check: func(k keyMap) bool { return slices.Equal(k.Grid.Keys(), []string{"g"}) },
+		},
+		{
+			name:      "override",
+			overrides: map[string]config.KeyList{"grid": {"F2", "f3"}},
+			check: func(k keyMap) bool {
+				return slices.Equal(k.Grid.Keys(), []string{"f2", "f3"}) && k.Grid.Help().Key == "f2/f3"
+			},
+		},
+		{
+			name:      "space by name",
+			overrides: map[string]config.KeyList{"pause": {"f4"}, "grid": {"Space"}},
+			check: func(k keyMap) bool {
+				return slices.Equal(k.Grid.Keys(), []string{" "}) && k.Grid.Help().Key == "space"
+			},
+		},
+		{
+			name:      "same key in different places",
+			overrides: map[string]config.KeyList{"search_up": {"q"}},
+			check:     func(k keyMap) bool { return slices.Equal(k.SearchUp.Keys(), []string{"q"}) },
+		},
+		{
+			name:      "unknown action",
+			overrides: map[string]config.KeyList{"fly": {"f"}},
+			wantErr:   `unknown action "fly"`,
+		},
+		{
+			name:      "empty key list",
+			overrides: map[string]config.KeyList{"help": {"", "  "}},
+			wantErr:   "help has no keys",
+		},
+		{
+			name:      "conflict",
+			overrides: map[string]config.KeyList{"grid": {"q"}},
+			wantErr:   `"q" is bound to grid and quit`,
+		},
+		{
+			name:      "conflict with space",
+			overrides: map[string]config.KeyList{"grid": {"space"}},
+			wantErr:   `"space" is bound to grid and pause`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := loadKeyMap(tt.overrides)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				if !slices.Equal(k.Grid.Keys(), []string{"g"}) {
+					t.Error("a bad keys section should leave the default keys")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.check(k) {
+				t.Error("overrides not applied")
+			}
+		})
+	}
+}
+
+func TestQuitOverrideKeepsCtrlC(t *testing.T) {
+	ctrlC := tea.KeyMsg{Type: tea.KeyCtrlC}
+
+	for _, keys := range []config.KeyList{{"f10"}, {"f10", "ctrl+c"}} {
+		k, err := loadKeyMap(map[string]config.KeyList{"quit": keys})
+		if err != nil {
+			t.Fatalf("quit %v: %v", keys, err)
+		}
+		if !key.Matches(ctrlC, k.Quit) {
+			t.Errorf("quit %v: ctrl+c no longer quits", keys)
+		}
+		if got := k.Quit.Keys(); !slices.Equal(got, []string{"f10", "ctrl+c"}) {
+			t.Errorf("quit %v: keys = %q", keys, got)
+		}
+	}
+}
+
+func TestNormalizeKey(t *testing.T) {
+	tests := map[string]string{
+		"space":  " ",
+		"SPACE":  " ",
+		" ":      " ",
+		"G":      "G",
+		" g ":    "g",
+		"Enter":  "enter",
+		"ctrl+S": "ctrl+s",
+		"":       "",
+	}
+	for in, want := range tests {
+		if got := normalizeKey(in); got != want {
+			t.Errorf("normalizeKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
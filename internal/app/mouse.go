@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/craigderington/skyterm/internal/render"
+)
+
+// mouseState tracks the pointer over the sky view
+type mouseState struct {
+	x, y     int  // Last pointer cell
+	hovering bool // Pointer is over the sky view
+	dragging bool // Left button held since a press on the sky view
+	dragged  bool // The view moved during the current press
+}
+
+// handleMouse selects on click, pans on drag and zooms with the wheel
+// around the pointer
+func (m *Model) handleMouse(msg tea.MouseMsg) {
+	if m.canvas == nil {
+		return
+	}
+
+	// Events over the status bar only end a drag
+	if msg.Y >= m.canvas.Height {
+		m.mouse.hovering = false
+		if msg.Action == tea.MouseActionRelease {
+			m.mouse.dragging = false
+		}
+		return
+	}
+
+	prevX, prevY := m.mouse.x, m.mouse.y
+	m.mouse.x, m.mouse.y = msg.X, msg.Y
+	m.mouse.hovering = true
+
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		m.zoomAt(msg.X, msg.Y, 1/m.config.Controls.ZoomStep)
+	case msg.Button == tea.MouseButtonWheelDown:
+		m.zoomAt(msg.X, msg.Y, m.config.Controls.ZoomStep)
+
+	case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+		m.mouse.dragging = true
+		m.mouse.dragged = false
+
+	case msg.Action == tea.MouseActionMotion && m.mouse.dragging:
+		if msg.X != prevX || msg.Y != prevY {
+			m.dragView(prevX, prevY, msg.X, msg.Y)
+			m.mouse.dragged = true
+		}
+
+	case msg.Action == tea.MouseActionRelease && m.mouse.dragging:
+		m.mouse.dragging = false
+		if !m.mouse.dragged {
+			m.SelectObjectAt(msg.X, msg.Y)
+		}
+	}
+}
+
+// cellPosition returns the sky position at the center of a screen cell
+func (m *Model) cellPosition(x, y int) (alt, az float64) {
+	return render.Unproject(float64(x)+0.5, float64(y)+0.5, m.altitude, m.azimuth, m.fov, m.canvas.Width, m.canvas.Height)
+}
+
+// dragView moves the view so the sky under the first cell follows the
+// pointer to the second. Dragging stops following the selection.
+func (m *Model) dragView(fromX, fromY, toX, toY int) {
+	fromAlt, fromAz := m.cellPosition(fromX, fromY)
+	toAlt, toAz := m.cellPosition(toX, toY)
+
+	m.shiftView(fromAlt-toAlt, fromAz-toAz)
+	m.following = false
+}
+
+// zoomAt scales the field of view by factor, keeping the sky under the
+// pointer in place. While following, the zoom stays on the selection.
+func (m *Model) zoomAt(x, y int, factor float64) {
+	before := m.fov
+	m.fov = math.Max(m.config.Controls.MinFOV, math.Min(120.0, m.fov*factor))
+	if m.following || m.fov == before {
+		return
+	}
+
+	// Position under the pointer at the old and new field
+	newFOV := m.fov
+	m.fov = before
+	oldAlt, oldAz := m.cellPosition(x, y)
+	m.fov = newFOV
+	newAlt, newAz := m.cellPosition(x, y)
+
+	m.shiftView(oldAlt-newAlt, oldAz-newAz)
+}
+
+// shiftView offsets the view center, taking the short way around in azimuth
+func (m *Model) shiftView(dAlt, dAz float64) {
+	dAz = math.Mod(dAz+540, 360) - 180
+
+	m.altitude = math.Max(-90.0, math.Min(90.0, m.altitude+dAlt))
+	m.azimuth = math.Mod(m.azimuth+dAz+360, 360)
+}
+
+// hoverTooltip returns the tooltip for the object under the pointer
+func (m *Model) hoverTooltip() (text string, ok bool) {
+	if !m.mouse.hovering || m.mouse.dragging || m.canvas == nil {
+		return "", false
+	}
+
+	obj := m.nearestObjectAt(m.mouse.x, m.mouse.y, pointRadius)
+	if obj == nil {
+		return "", false
+	}
+	alt, az, ok := obj.position()
+	if !ok {
+		return "", false
+	}
+
+	name := obj.Name
+	if obj.DeepSky != nil && obj.DeepSky.CommonName != "" {
+		name += " " + obj.DeepSky.CommonName
+	}
+	precision := m.coordPrecision()
+	return fmt.Sprintf("%s  Alt %.*f° Az %.*f°", name, precision, alt, precision, az), true
+}
@@ -1,62 +1,72 @@
 package app
 
 import (
-	"strings"
+	"github.com/craigderington/skyterm/internal/ui"
 )
 
-// performSearch searches for objects matching the query and selects the first match
-func (m *Model) performSearch() {
-	if m.searchQuery == "" {
-		return
-	}
+// maxSearchResults is the number of results listed in the search box
+const maxSearchResults = 8
 
-	query := strings.ToLower(strings.TrimSpace(m.searchQuery))
+// searchItems lists every searchable object with its current position
+func (m *Model) searchItems() []ui.SearchItem {
+	var items []ui.SearchItem
 
-	// Search stars
 	for _, star := range m.starCatalog.Stars() {
-		if strings.Contains(strings.ToLower(star.Name), query) {
-			starCopy := star
-			m.selectedObject = &SelectedObject{
-				Type: "star",
-				Name: star.Name,
-				Star: &starCopy,
-			}
-			m.CenterOnSelected()
-			m.showInfo = true
-			return
-		}
+		items = append(items, ui.SearchItem{
+			Type:      "star",
+			Name:      star.Name,
+			Magnitude: star.Magnitude,
+			Altitude:  star.Altitude,
+		})
 	}
 
-	// Search planets
 	if m.planetarySystem != nil {
 		for _, planet := range m.planetarySystem.AllPlanets() {
-			if strings.Contains(strings.ToLower(planet.Name), query) {
-				planetCopy := planet
-				m.selectedObject = &SelectedObject{
-					Type:   "planet",
-					Name:   planet.Name,
-					Planet: &planetCopy,
-				}
-				m.CenterOnSelected()
-				m.showInfo = true
-				return
-			}
+			items = append(items, ui.SearchItem{
+				Type:      "planet",
+				Name:      planet.Name,
+				Magnitude: planet.Magnitude,
+				Altitude:  planet.Altitude,
+			})
 		}
 	}
 
-	// Search deep sky
 	for _, obj := range m.deepSkyCatalog.Objects() {
-		objName := strings.ToLower(obj.Name + " " + obj.CommonName)
-		if strings.Contains(objName, query) {
-			objCopy := obj
-			m.selectedObject = &SelectedObject{
-				Type:    "deepsky",
-				Name:    obj.Name,
-				DeepSky: &objCopy,
-			}
-			m.CenterOnSelected()
-			m.showInfo = true
-			return
-		}
+		items = append(items, ui.SearchItem{
+			Type:      "deepsky",
+			Name:      obj.Name,
+			Aliases:   append([]string{obj.CommonName}, obj.Aliases...),
+			Magnitude: obj.Magnitude,
+			Altitude:  obj.Altitude,
+		})
+	}
+
+	return items
+}
+
+// updateSearch ranks results for the current query and resets the choice
+// to the best match
+func (m *Model) updateSearch() {
+	m.searchResults = ui.Search(m.searchQuery, m.searchItems(), maxSearchResults)
+	m.searchIndex = 0
+}
+
+// performSearch selects the chosen search result and centers on it
+func (m *Model) performSearch() {
+	if m.searchIndex >= len(m.searchResults) {
+		return
+	}
+	m.selectSearchResult(m.searchResults[m.searchIndex])
+	m.CenterOnSelected()
+	m.showInfo = true
+}
+
+// selectSearchResult selects a result, filling in the object from the
+// catalogs by name
+func (m *Model) selectSearchResult(result ui.SearchResult) {
+	m.selectedObject = &SelectedObject{
+		Type: result.Type,
+		Name: result.Name,
 	}
+	m.refreshSelection()
 }
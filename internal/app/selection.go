@@ -10,13 +10,14 @@ import (
 
 // SelectedObject represents the currently selected celestial object
 type SelectedObject struct {
-	Type string // "star", "planet", "deepsky", "constellation"
+	Type string // "star", "planet", "deepsky", "marker"
 	Name string
 
 	// Object-specific data
 	Star     *catalog.Star
 	Planet   *astro.Planet
 	DeepSky  *catalog.MessierObject
+	Marker   *catalog.Marker
 }
 
 // ClearSelection clears the current selection
@@ -25,26 +26,49 @@ func (m *Model) ClearSelection() {
 	m.following = false
 }
 
+// Search radii in cells around the view center and around the mouse pointer
+const (
+	selectRadius = 15.0
+	pointRadius  = 3.0
+)
+
 // SelectNearestObject finds and selects the nearest object to the view center
 func (m *Model) SelectNearestObject() {
 	if m.canvas == nil {
 		return
 	}
 
-	centerX := m.canvas.Width / 2
-	centerY := m.canvas.Height / 2
+	m.selectedObject = m.nearestObjectAt(m.canvas.Width/2, m.canvas.Height/2, selectRadius)
+}
+
+// SelectObjectAt selects the object under a screen cell, such as a mouse
+// click, or clears the selection when there is none
+func (m *Model) SelectObjectAt(x, y int) {
+	if m.canvas == nil {
+		return
+	}
+
+	m.selectedObject = m.nearestObjectAt(x, y, pointRadius)
+	if m.selectedObject == nil {
+		m.following = false
+	}
+}
 
+// nearestObjectAt returns the visible object closest to a screen cell within
+// radius cells, or nil
+func (m *Model) nearestObjectAt(x, y int, radius float64) *SelectedObject {
 	minDist := math.MaxFloat64
 	var nearest *SelectedObject
+	vis := m.visibility()
 
 	// Check stars
 	for _, star := range m.starCatalog.Stars() {
-		if star.Magnitude > m.magnitudeLimit {
+		if !vis.Visible(star.Magnitude, star.Altitude, star.Azimuth) {
 			continue
 		}
 
-		dist := m.distanceToObject(star.Altitude, star.Azimuth, centerX, centerY)
-		if dist < minDist && dist < 15.0 { // Within 15 pixel radius
+		dist := m.distanceToObject(star.Altitude, star.Azimuth, x, y)
+		if dist < minDist && dist < radius {
 			minDist = dist
 			starCopy := star
 			nearest = &SelectedObject{
@@ -58,8 +82,12 @@ func (m *Model) SelectNearestObject() {
 	// Check planets (if visible)
 	if m.showPlanets && m.planetarySystem != nil {
 		for _, planet := range m.planetarySystem.AllPlanets() {
-			dist := m.distanceToObject(planet.Altitude, planet.Azimuth, centerX, centerY)
-			if dist < minDist && dist < 15.0 {
+			if !vis.PlanetVisible(planet) {
+				continue
+			}
+
+			dist := m.distanceToObject(planet.Altitude, planet.Azimuth, x, y)
+			if dist < minDist && dist < radius {
 				minDist = dist
 				planetCopy := planet
 				nearest = &SelectedObject{
@@ -74,12 +102,12 @@ func (m *Model) SelectNearestObject() {
 	// Check deep sky (if visible)
 	if m.showDeepSky {
 		for _, obj := range m.deepSkyCatalog.Objects() {
-			if obj.Magnitude > m.magnitudeLimit+3 {
+			if !vis.DeepSkyVisible(obj.Magnitude, obj.Altitude, obj.Azimuth) {
 				continue
 			}
 
-			dist := m.distanceToObject(obj.Altitude, obj.Azimuth, centerX, centerY)
-			if dist < minDist && dist < 15.0 {
+			dist := m.distanceToObject(obj.Altitude, obj.Azimuth, x, y)
+			if dist < minDist && dist < radius {
 				minDist = dist
 				objCopy := obj
 				nearest = &SelectedObject{
@@ -91,56 +119,75 @@ func (m *Model) SelectNearestObject() {
 		}
 	}
 
-	m.selectedObject = nearest
+	// Check the go-to marker
+	if m.marker != nil {
+		dist := m.distanceToObject(m.marker.Altitude, m.marker.Azimuth, x, y)
+		if dist < minDist && dist < radius {
+			nearest = &SelectedObject{
+				Type:   "marker",
+				Name:   m.marker.Name,
+				Marker: m.marker,
+			}
+		}
+	}
+
+	return nearest
 }
 
 // CenterOnSelected centers the view on the selected object
 func (m *Model) CenterOnSelected() {
+	if alt, az, ok := m.selectedPosition(); ok {
+		m.altitude = alt
+		m.azimuth = az
+	}
+}
+
+// selectedPosition returns the current position of the selected object
+func (m *Model) selectedPosition() (alt, az float64, ok bool) {
 	if m.selectedObject == nil {
-		return
+		return 0, 0, false
 	}
 
-	var alt, az float64
+	return m.selectedObject.position()
+}
 
-	switch m.selectedObject.Type {
+// position returns the object's altitude and azimuth
+func (o *SelectedObject) position() (alt, az float64, ok bool) {
+	switch o.Type {
 	case "star":
-		if m.selectedObject.Star != nil {
-			alt = m.selectedObject.Star.Altitude
-			az = m.selectedObject.Star.Azimuth
+		if o.Star != nil {
+			return o.Star.Altitude, o.Star.Azimuth, true
 		}
 	case "planet":
-		if m.selectedObject.Planet != nil {
-			alt = m.selectedObject.Planet.Altitude
-			az = m.selectedObject.Planet.Azimuth
+		if o.Planet != nil {
+			return o.Planet.Altitude, o.Planet.Azimuth, true
 		}
 	case "deepsky":
-		if m.selectedObject.DeepSky != nil {
-			alt = m.selectedObject.DeepSky.Altitude
-			az = m.selectedObject.DeepSky.Azimuth
+		if o.DeepSky != nil {
+			return o.DeepSky.Altitude, o.DeepSky.Azimuth, true
+		}
+	case "marker":
+		if o.Marker != nil {
+			return o.Marker.Altitude, o.Marker.Azimuth, true
 		}
 	}
 
-	if alt != 0 || az != 0 {
-		m.altitude = alt
-		m.azimuth = az
-	}
+	return 0, 0, false
 }
 
-// UpdateFollowing updates view to follow selected object
-func (m *Model) UpdateFollowing() {
-	if !m.following || m.selectedObject == nil {
+// refreshSelection copies the selected object's recomputed position from
+// the catalogs after a time change
+func (m *Model) refreshSelection() {
+	if m.selectedObject == nil {
 		return
 	}
 
-	// Update selected object's position
 	switch m.selectedObject.Type {
 	case "star":
 		for _, star := range m.starCatalog.Stars() {
 			if star.Name == m.selectedObject.Name {
 				starCopy := star
 				m.selectedObject.Star = &starCopy
-				m.altitude = star.Altitude
-				m.azimuth = star.Azimuth
 				break
 			}
 		}
@@ -149,8 +196,6 @@ func (m *Model) UpdateFollowing() {
 			if planet.Name == m.selectedObject.Name {
 				planetCopy := planet
 				m.selectedObject.Planet = &planetCopy
-				m.altitude = planet.Altitude
-				m.azimuth = planet.Azimuth
 				break
 			}
 		}
@@ -159,16 +204,29 @@ func (m *Model) UpdateFollowing() {
 			if obj.Name == m.selectedObject.Name {
 				objCopy := obj
 				m.selectedObject.DeepSky = &objCopy
-				m.altitude = obj.Altitude
-				m.azimuth = obj.Azimuth
 				break
 			}
 		}
+	case "marker":
+		// The marker is updated in place; a cleared marker ends the selection
+		m.selectedObject.Marker = m.marker
+		if m.marker == nil {
+			m.ClearSelection()
+		}
 	}
 }
 
-// distanceToObject calculates screen distance from screen center to object position
-func (m *Model) distanceToObject(alt, az float64, centerX, centerY int) float64 {
+// UpdateFollowing updates view to follow selected object
+func (m *Model) UpdateFollowing() {
+	if !m.following || m.selectedObject == nil {
+		return
+	}
+
+	m.CenterOnSelected()
+}
+
+// distanceToObject calculates screen distance from a screen cell to object position
+func (m *Model) distanceToObject(alt, az float64, x0, y0 int) float64 {
 	// Project object to screen coordinates
 	x, y, visible := render.Project(alt, az, m.altitude, m.azimuth, m.fov, m.canvas.Width, m.canvas.Height)
 
@@ -177,8 +235,8 @@ func (m *Model) distanceToObject(alt, az float64, centerX, centerY int) float64
 		return math.MaxFloat64
 	}
 
-	// Calculate pixel distance from center
-	dx := float64(x - centerX)
-	dy := float64(y - centerY)
+	// Calculate pixel distance from the cell
+	dx := float64(x - x0)
+	dy := float64(y - y0)
 	return math.Sqrt(dx*dx + dy*dy)
 }
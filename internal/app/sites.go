@@ -0,0 +1,281 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/craigderington/skyterm/internal/config"
+	"github.com/craigderington/skyterm/internal/gazetteer"
+	"github.com/craigderington/skyterm/internal/ui"
+)
+
+// maxPlaceResults is the number of gazetteer cities offered in the site
+// picker
+const maxPlaceResults = 6
+
+// siteChoice is a site offered by the picker
+type siteChoice struct {
+	site   config.SiteConfig
+	detail string
+	manual bool // Typed as coordinates
+}
+
+// openSitePicker shows every configured site
+func (m *Model) openSitePicker() {
+	m.siteMode = true
+	m.siteInput = ""
+	m.siteError = ""
+	m.updateSiteChoices()
+}
+
+// updateSiteChoices lists the configured sites whose names contain the
+// input, then gazetteer cities starting with it. Input that reads as
+// coordinates is offered first as a manual site.
+func (m *Model) updateSiteChoices() {
+	m.siteChoices = nil
+	if site, err := manualSite(strings.Fields(m.siteInput)); err == nil {
+		detail := fmt.Sprintf("%.0f m, %s", site.Elevation, nearestPlace(site.Latitude, site.Longitude))
+		m.siteChoices = append(m.siteChoices, siteChoice{site: site, detail: detail, manual: true})
+	}
+
+	query := strings.ToLower(strings.TrimSpace(m.siteInput))
+	for _, site := range m.config.AllSites() {
+		if strings.Contains(strings.ToLower(site.Name), query) {
+			m.siteChoices = append(m.siteChoices, siteChoice{site: site, detail: siteDetail(site)})
+		}
+	}
+
+	for _, city := range gazetteer.Search(m.siteInput, maxPlaceResults) {
+		site := citySite(city)
+		m.siteChoices = append(m.siteChoices, siteChoice{site: site, detail: city.Place() + " " + siteDetail(site)})
+	}
+	m.siteIndex = 0
+}
+
+// siteChoicesView describes the listed sites for the picker
+func (m *Model) siteChoicesView() []ui.SiteChoice {
+	choices := make([]ui.SiteChoice, len(m.siteChoices))
+	for i, c := range m.siteChoices {
+		choices[i] = ui.SiteChoice{
+			Name:    c.site.Name,
+			Detail:  c.detail,
+			Current: c.site.Name == m.observer.Name && c.site.Latitude == m.observer.Latitude && c.site.Longitude == m.observer.Longitude,
+		}
+	}
+	return choices
+}
+
+// siteDetail summarizes a site's coordinates and settings
+func siteDetail(site config.SiteConfig) string {
+	detail := fmt.Sprintf("%s %.0f m", formatLatLon(site.Latitude, site.Longitude), site.Elevation)
+	if len(site.Horizon) > 0 {
+		detail += ", horizon"
+	}
+	if site.MagnitudeLimit > 0 {
+		detail += fmt.Sprintf(", mag %.1f", site.MagnitudeLimit)
+	}
+	if site.TimeZone != "" {
+		detail += ", " + site.TimeZone
+	}
+	return detail
+}
+
+// handleSiteKey moves through, picks or cancels the site picker
+func (m *Model) handleSiteKey(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, m.keys.SiteCancel):
+		m.siteMode = false
+	case key.Matches(msg, m.keys.SiteSelect):
+		if m.siteIndex >= len(m.siteChoices) {
+			m.siteError = fmt.Sprintf("No site matches %q", m.siteInput)
+			return
+		}
+		choice := m.siteChoices[m.siteIndex]
+		m.setSite(choice.site)
+		if choice.manual {
+			m.statusMessage = "Observing " + nearestPlace(choice.site.Latitude, choice.site.Longitude)
+		}
+		m.siteMode = false
+	case key.Matches(msg, m.keys.SiteUp):
+		if m.siteIndex > 0 {
+			m.siteIndex--
+		}
+	case key.Matches(msg, m.keys.SiteDown):
+		if m.siteIndex < len(m.siteChoices)-1 {
+			m.siteIndex++
+		}
+	case msg.String() == "backspace":
+		if len(m.siteInput) > 0 {
+			m.siteInput = m.siteInput[:len(m.siteInput)-1]
+		}
+		m.siteError = ""
+		m.updateSiteChoices()
+	default:
+		if len(msg.Runes) > 0 {
+			m.siteInput += string(msg.Runes)
+			m.siteError = ""
+			m.updateSiteChoices()
+		}
+	}
+}
+
+// setSite moves the observer to a site, keeping the current extinction
+// coefficient. A site's magnitude limit replaces the current one.
+func (m *Model) setSite(site config.SiteConfig) {
+	m.observer = site.Observer(m.observer.ExtinctionCoefficient)
+	if site.MagnitudeLimit > 0 {
+		m.magnitudeLimit = site.MagnitudeLimit
+	}
+	m.starTrails.Reset()
+	m.applyZone()
+	m.updatePositions()
+}
+
+// SiteFlags are the command line choices of where to observe from. Nil
+// and empty fields were not given.
+type SiteFlags struct {
+	Lat, Lon  *float64 // Coordinates, given together
+	Elevation *float64 // Meters above sea level
+	Site      string   // A configured site or gazetteer city
+	Place     string   // A gazetteer city
+}
+
+// StartSite picks the site from --lat/--lon, --site or --place; --elev
+// alone changes the elevation of the configured site. It returns nil when
+// none of them is given.
+func StartSite(cfg *config.Config, f SiteFlags) (*config.SiteConfig, error) {
+	if (f.Lat == nil) != (f.Lon == nil) {
+		return nil, errors.New("--lat and --lon must be given together")
+	}
+	choices := 0
+	for _, given := range []bool{f.Lat != nil, f.Site != "", f.Place != ""} {
+		if given {
+			choices++
+		}
+	}
+	if choices > 1 {
+		return nil, errors.New("use only one of --lat/--lon, --site and --place")
+	}
+
+	elevation := 0.0
+	if f.Elevation != nil {
+		elevation = *f.Elevation
+	}
+
+	var site config.SiteConfig
+	var err error
+	switch {
+	case f.Lat != nil:
+		site, err = CoordinateSite(*f.Lat, *f.Lon, elevation)
+	case f.Site != "":
+		site, err = FindSite(cfg, f.Site)
+	case f.Place != "":
+		site, err = PlaceSite(f.Place)
+	case f.Elevation != nil:
+		site = cfg.StartSite()
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Elevation != nil {
+		site.Elevation = elevation
+	}
+	return &site, nil
+}
+
+// manualZoneRange is how far in km the nearest city can be for its time
+// zone to be taken for a manual site
+const manualZoneRange = 300
+
+// manualSite reads "lat lon [elevation]" as a site
+func manualSite(args []string) (config.SiteConfig, error) {
+	names := []string{"<lat>", "<lon>", "[elevation]"}
+	if len(args) == 2 {
+		names = names[:2]
+	}
+	values, err := parseNumbers(args, names...)
+	if err != nil {
+		return config.SiteConfig{}, err
+	}
+	elevation := 0.0
+	if len(values) == 3 {
+		elevation = values[2]
+	}
+	return CoordinateSite(values[0], values[1], elevation)
+}
+
+// CoordinateSite is a site at coordinates, named by them and in the time
+// zone of the nearest city if one is close. Longitudes up to 360° east are
+// accepted.
+func CoordinateSite(lat, lon, elevation float64) (config.SiteConfig, error) {
+	if lat < -90 || lat > 90 || lon < -180 || lon > 360 {
+		return config.SiteConfig{}, fmt.Errorf("latitude must be within ±90° and longitude within ±180°")
+	}
+	if lon > 180 {
+		lon -= 360
+	}
+
+	site := config.SiteConfig{
+		Name:      formatLatLon(lat, lon),
+		Latitude:  lat,
+		Longitude: lon,
+		Elevation: elevation,
+	}
+	if city, d := gazetteer.Nearest(lat, lon); d <= manualZoneRange {
+		site.TimeZone = city.TimeZone
+	}
+	return site, nil
+}
+
+// citySite is a gazetteer city as a site
+func citySite(c gazetteer.City) config.SiteConfig {
+	return config.SiteConfig{
+		Name:      c.Name,
+		Latitude:  c.Latitude,
+		Longitude: c.Longitude,
+		Elevation: c.Elevation,
+		TimeZone:  c.TimeZone,
+	}
+}
+
+// FindSite looks a name up among the configured sites, then the gazetteer
+func FindSite(cfg *config.Config, name string) (config.SiteConfig, error) {
+	if site, ok := cfg.FindSite(name); ok {
+		return site, nil
+	}
+	if len(gazetteer.Find(name)) == 0 {
+		return config.SiteConfig{}, fmt.Errorf("no site or place named %q", name)
+	}
+	return PlaceSite(name)
+}
+
+// PlaceSite resolves a city name from the gazetteer, e.g. "Flagstaff" or
+// "Portland, ME". A name shared by several cities is an error listing them.
+func PlaceSite(name string) (config.SiteConfig, error) {
+	cities := gazetteer.Find(name)
+	switch len(cities) {
+	case 0:
+		return config.SiteConfig{}, fmt.Errorf("unknown place %q", name)
+	case 1:
+		return citySite(cities[0]), nil
+	}
+
+	choices := make([]string, len(cities))
+	for i, c := range cities {
+		choices[i] = c.String()
+	}
+	return config.SiteConfig{}, fmt.Errorf("place %q is ambiguous; add a region or country: %s", name, strings.Join(choices, "; "))
+}
+
+// nearestPlace describes where coordinates are, e.g. "12 km from
+// Flagstaff, AZ, US"
+func nearestPlace(lat, lon float64) string {
+	city, d := gazetteer.Nearest(lat, lon)
+	return fmt.Sprintf("%.0f km from %s", d, city)
+}
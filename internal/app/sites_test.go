@@ -0,0 +1,81 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/craigderington/skyterm/internal/config"
+)
+
+func TestStartSite(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Sites = []config.SiteConfig{{Name: "Dark Site", Latitude: 38.5, Longitude: -107.7, Elevation: 2400, TimeZone: "America/Denver"}}
+	num := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name  string
+		flags SiteFlags
+		want  string  // Site name, empty for no site
+		elev  float64 // Expected elevation when want is set
+		err   string  // Part of the error
+	}{
+		{name: "none"},
+		{name: "lat alone", flags: SiteFlags{Lat: num(35)}, err: "--lat and --lon must be given together"},
+		{name: "lon alone", flags: SiteFlags{Lon: num(-111)}, err: "--lat and --lon must be given together"},
+		{name: "lat and site", flags: SiteFlags{Lat: num(35), Lon: num(-111), Site: "Dark Site"}, err: "use only one"},
+		{name: "site and place", flags: SiteFlags{Site: "Dark Site", Place: "Flagstaff"}, err: "use only one"},
+		{name: "lat and place", flags: SiteFlags{Lat: num(35), Lon: num(-111), Place: "Flagstaff"}, err: "use only one"},
+		{name: "coordinates", flags: SiteFlags{Lat: num(35.2), Lon: num(-111.65)}, want: "35.20°N 111.65°W"},
+		{name: "coordinates with elev", flags: SiteFlags{Lat: num(35.2), Lon: num(-111.65), Elevation: num(2100)}, want: "35.20°N 111.65°W", elev: 2100},
+		{name: "bad coordinates", flags: SiteFlags{Lat: num(95), Lon: num(0)}, err: "latitude must be within"},
+		{name: "site", flags: SiteFlags{Site: "dark site"}, want: "Dark Site", elev: 2400},
+		{name: "site with elev", flags: SiteFlags{Site: "Dark Site", Elevation: num(2500)}, want: "Dark Site", elev: 2500},
+		{name: "site from gazetteer", flags: SiteFlags{Site: "Flagstaff"}, want: "Flagstaff", elev: -1},
+		{name: "unknown site", flags: SiteFlags{Site: "Atlantis"}, err: `no site or place named "Atlantis"`},
+		{name: "place", flags: SiteFlags{Place: "Portland, ME"}, want: "Portland", elev: -1},
+		{name: "ambiguous place", flags: SiteFlags{Place: "Portland"}, err: "ambiguous"},
+		{name: "elev alone", flags: SiteFlags{Elevation: num(123)}, want: cfg.Location.Name, elev: 123},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site, err := StartSite(cfg, tt.flags)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == "" {
+				if site != nil {
+					t.Errorf("site = %+v, want none", site)
+				}
+				return
+			}
+			if site == nil {
+				t.Fatal("no site")
+			}
+			if site.Name != tt.want {
+				t.Errorf("site = %q, want %q", site.Name, tt.want)
+			}
+			if tt.elev >= 0 && site.Elevation != tt.elev {
+				t.Errorf("elevation = %v, want %v", site.Elevation, tt.elev)
+			}
+		})
+	}
+}
+
+func TestStartSiteElevAloneKeepsConfiguredSite(t *testing.T) {
+	cfg := config.DefaultConfig()
+	elev := 500.0
+	site, err := StartSite(cfg, SiteFlags{Elevation: &elev})
+	if err != nil {
+		t.Fatal(err)
+	}
+	home := cfg.StartSite()
+	if site.Latitude != home.Latitude || site.Longitude != home.Longitude || site.TimeZone != home.TimeZone {
+		t.Errorf("site = %+v, want %+v at 500 m", site, home)
+	}
+}
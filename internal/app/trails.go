@@ -0,0 +1,53 @@
+package app
+
+import "github.com/craigderington/skyterm/internal/astro"
+
+// trailMode selects which bodies leave motion trails
+type trailMode int
+
+const (
+	trailsOff trailMode = iota
+	trailsSelected
+	trailsAll
+)
+
+// trailBodies are the bodies traced in trailsAll mode. The Sun is left out
+// since its path is simply the ecliptic.
+var trailBodies = []string{"Moon", "Mercury", "Venus", "Mars", "Jupiter", "Saturn", "Uranus", "Neptune"}
+
+// cycleTrails steps through off, the selected body and all bodies. The
+// selected-body mode is skipped unless a planet, the Sun or the Moon is
+// selected.
+func (m *Model) cycleTrails() {
+	switch m.trailMode {
+	case trailsOff:
+		if m.selectedObject != nil && m.selectedObject.Type == "planet" {
+			m.trailMode = trailsSelected
+		} else {
+			m.trailMode = trailsAll
+		}
+	case trailsSelected:
+		m.trailMode = trailsAll
+	default:
+		m.trailMode = trailsOff
+	}
+
+	m.buildTrails()
+}
+
+// buildTrails samples trails around the current time. The samples are kept
+// in RA/Dec, so the trails stay fixed against the stars as time runs.
+func (m *Model) buildTrails() {
+	var bodies []string
+	switch m.trailMode {
+	case trailsSelected:
+		bodies = []string{m.selectedObject.Name}
+	case trailsAll:
+		bodies = trailBodies
+	}
+
+	m.trails = astro.CalculateTrails(bodies, m.currentTime, m.trailSpan, m.trailStep, m.observer)
+	for i := range m.trails {
+		m.trails[i].UpdatePositions(m.observer, m.currentTime)
+	}
+}
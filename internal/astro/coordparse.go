@@ -0,0 +1,190 @@
+package astro
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CoordinateFrame identifies the frame of user-entered coordinates
+type CoordinateFrame int
+
+const (
+	FrameJ2000      CoordinateFrame = iota // Equatorial, mean equinox of J2000
+	FrameJNow                              // Equatorial, equinox of date
+	FrameHorizontal                        // Altitude and azimuth
+)
+
+func (f CoordinateFrame) String() string {
+	switch f {
+	case FrameJNow:
+		return "JNow"
+	case FrameHorizontal:
+		return "Alt/Az"
+	default:
+		return "J2000"
+	}
+}
+
+// Coordinates is a position parsed from user input
+type Coordinates struct {
+	Frame    CoordinateFrame
+	RA       float64 // Hours, for equatorial frames
+	Dec      float64 // Degrees, for equatorial frames
+	Altitude float64 // Degrees, for FrameHorizontal
+	Azimuth  float64 // Degrees, for FrameHorizontal
+}
+
+// frameKeywords select the frame when they appear anywhere in the input
+var frameKeywords = map[string]CoordinateFrame{
+	"j2000":      FrameJ2000,
+	"jnow":       FrameJNow,
+	"altaz":      FrameHorizontal,
+	"alt/az":     FrameHorizontal,
+	"horizontal": FrameHorizontal,
+}
+
+var numberPattern = regexp.MustCompile(`\d+(?:\.\d*)?|\.\d+`)
+
+// ParseCoordinates reads a pair of coordinates, RA/Dec in J2000 unless the
+// input names another frame with "jnow" or "altaz". Each value may be
+// sexagesimal, with units or separators (05h35m17s, 05:35:17, 05 35 17,
+// -05°23'28", -5d23m28s), or decimal. A decimal RA is in degrees unless it
+// ends in "h". The two values are separated by a comma, by whitespace when
+// each is a single word, or by the sign of the second.
+func ParseCoordinates(input string) (Coordinates, error) {
+	coords := Coordinates{Frame: FrameJ2000}
+
+	var fields []string
+	for _, field := range strings.Fields(strings.ToLower(input)) {
+		if frame, ok := frameKeywords[field]; ok {
+			coords.Frame = frame
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	first, second, err := splitCoordinates(fields)
+	if err != nil {
+		return Coordinates{}, err
+	}
+
+	if coords.Frame == FrameHorizontal {
+		alt, _, err := parseAngle(first)
+		if err != nil {
+			return Coordinates{}, fmt.Errorf("altitude: %w", err)
+		}
+		az, _, err := parseAngle(second)
+		if err != nil {
+			return Coordinates{}, fmt.Errorf("azimuth: %w", err)
+		}
+		if alt < -90 || alt > 90 {
+			return Coordinates{}, fmt.Errorf("altitude %.2f° is out of range", alt)
+		}
+		if az < 0 || az >= 360 {
+			return Coordinates{}, fmt.Errorf("azimuth %.2f° is out of range", az)
+		}
+		coords.Altitude, coords.Azimuth = alt, az
+		return coords, nil
+	}
+
+	ra, hours, err := parseAngle(first)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("RA: %w", err)
+	}
+	if !hours {
+		ra /= 15.0
+	}
+	dec, _, err := parseAngle(second)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("Dec: %w", err)
+	}
+	if ra < 0 || ra >= 24 {
+		return Coordinates{}, fmt.Errorf("RA %.4fh is out of range", ra)
+	}
+	if dec < -90 || dec > 90 {
+		return Coordinates{}, fmt.Errorf("Dec %.4f° is out of range", dec)
+	}
+	coords.RA, coords.Dec = ra, dec
+	return coords, nil
+}
+
+// splitCoordinates divides the words of the input into its two values
+func splitCoordinates(fields []string) (first, second string, err error) {
+	joined := strings.Join(fields, " ")
+	if a, b, ok := strings.Cut(joined, ","); ok {
+		return strings.TrimSpace(a), strings.TrimSpace(b), nil
+	}
+
+	switch {
+	case len(fields) == 2:
+		return fields[0], fields[1], nil
+	case len(fields) == 4 || len(fields) == 6:
+		// Space-separated sexagesimal, split evenly
+		half := len(fields) / 2
+		return strings.Join(fields[:half], " "), strings.Join(fields[half:], " "), nil
+	}
+
+	// Otherwise the second value starts at the first explicit sign
+	for i := 1; i < len(fields); i++ {
+		if strings.HasPrefix(fields[i], "+") || strings.HasPrefix(fields[i], "-") {
+			return strings.Join(fields[:i], " "), strings.Join(fields[i:], " "), nil
+		}
+	}
+
+	return "", "", fmt.Errorf("expected two coordinates, such as 05h35m17s -05°23'28\"")
+}
+
+// parseAngle reads one sexagesimal or decimal value. hours reports whether
+// it was written in hours (05h35m17s, 5.5h) or as bare sexagesimal, which
+// for RA conventionally means hours.
+func parseAngle(s string) (value float64, hours bool, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false, fmt.Errorf("missing value")
+	}
+
+	negative := strings.HasPrefix(s, "-") || strings.HasPrefix(s, "−")
+	hours = strings.Contains(s, "h")
+
+	numbers := numberPattern.FindAllString(s, -1)
+	if len(numbers) == 0 || len(numbers) > 3 {
+		return 0, false, fmt.Errorf("can't read %q", s)
+	}
+
+	// Anything other than numbers, signs, separators and units is an error
+	rest := numberPattern.ReplaceAllString(s, "")
+	if strings.Trim(rest, "+-−hmsd°'\"′″: ") != "" {
+		return 0, false, fmt.Errorf("can't read %q", s)
+	}
+
+	scale := 1.0
+	for i, n := range numbers {
+		v, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("can't read %q", s)
+		}
+		if i > 0 && v >= 60 {
+			return 0, false, fmt.Errorf("%q: minutes and seconds must be below 60", s)
+		}
+		value += v / scale
+		scale *= 60
+	}
+
+	if len(numbers) > 1 && !strings.ContainsAny(s, "d°") {
+		hours = true
+	}
+	if negative {
+		value = -value
+	}
+	return value, hours, nil
+}
+
+// FormatCoordinates renders coordinates in the frame they were given in
+func FormatCoordinates(c Coordinates) string {
+	if c.Frame == FrameHorizontal {
+		return fmt.Sprintf("Alt %.2f° Az %.2f°", c.Altitude, c.Azimuth)
+	}
+	return fmt.Sprintf("%s %s (%s)", FormatRA(c.RA), FormatDec(c.Dec), c.Frame)
+}
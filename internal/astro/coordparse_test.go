@@ -0,0 +1,63 @@
+package astro
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseCoordinatesEquatorial(t *testing.T) {
+	// Orion Nebula in every supported notation
+	const ra, dec = 5.0 + 35.0/60 + 17.0/3600, -(5.0 + 23.0/60 + 28.0/3600)
+
+	for _, input := range []string{
+		`05h35m17s -05°23'28"`,
+		`05:35:17 -05:23:28`,
+		`05 35 17 -05 23 28`,
+		`05h 35m 17s -05° 23' 28"`,
+		`5h35m17s, -5d23m28s`,
+		`83.8208 -5.3911`,
+		`5.58806h -5.3911`,
+		`J2000 05h35m17s −05°23′28″`,
+	} {
+		c, err := ParseCoordinates(input)
+		if err != nil {
+			t.Errorf("%s: %v", input, err)
+			continue
+		}
+		if c.Frame != FrameJ2000 {
+			t.Errorf("%s: frame %v, want J2000", input, c.Frame)
+		}
+		if math.Abs(c.RA-ra) > 1e-4 || math.Abs(c.Dec-dec) > 1e-4 {
+			t.Errorf("%s: got %.5f/%.5f, want %.5f/%.5f", input, c.RA, c.Dec, ra, dec)
+		}
+	}
+}
+
+func TestParseCoordinatesFrames(t *testing.T) {
+	c, err := ParseCoordinates("jnow 12h00m +45 30")
+	if err != nil || c.Frame != FrameJNow || c.RA != 12 || c.Dec != 45.5 {
+		t.Errorf("JNow: got %+v, %v", c, err)
+	}
+
+	c, err = ParseCoordinates("altaz 30 225.5")
+	if err != nil || c.Frame != FrameHorizontal || c.Altitude != 30 || c.Azimuth != 225.5 {
+		t.Errorf("Alt/Az: got %+v, %v", c, err)
+	}
+}
+
+func TestParseCoordinatesErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"12h30m",
+		"25h00m +10",
+		"10h00m +95",
+		"10h75m +10",
+		"altaz 95 10",
+		"altaz 10 360",
+		"vega",
+	} {
+		if c, err := ParseCoordinates(input); err == nil {
+			t.Errorf("%q: expected an error, got %+v", input, c)
+		}
+	}
+}
@@ -3,6 +3,11 @@ package astro
 import (
 	"math"
 	"time"
+
+	"github.com/soniakeys/meeus/v3/base"
+	"github.com/soniakeys/meeus/v3/coord"
+	"github.com/soniakeys/meeus/v3/precess"
+	"github.com/soniakeys/unit"
 )
 
 // EquatorialCoords represents equatorial coordinates (RA/Dec)
@@ -17,13 +22,15 @@ type HorizontalCoords struct {
 	Azimuth  float64 // Azimuth in degrees (0-360, 0=North)
 }
 
-// EquatorialToHorizontal converts RA/Dec to Alt/Az for given observer and time
+// EquatorialToHorizontal converts RA/Dec to Alt/Az for given observer and time.
+// Both angles come from atan2 so precision holds near the zenith and the
+// meridian, where asin/acos lose digits.
 func EquatorialToHorizontal(eq EquatorialCoords, observer *Observer, t time.Time) HorizontalCoords {
 	// Get Local Sidereal Time
 	lst := observer.LST(t)
 
 	// Convert to radians
-	raRad := eq.RA * 15.0 * math.Pi / 180.0  // Hours to degrees to radians
+	raRad := eq.RA * 15.0 * math.Pi / 180.0 // Hours to degrees to radians
 	decRad := eq.Dec * math.Pi / 180.0
 	latRad := observer.Latitude * math.Pi / 180.0
 	lstRad := lst * 15.0 * math.Pi / 180.0
@@ -31,22 +38,15 @@ func EquatorialToHorizontal(eq EquatorialCoords, observer *Observer, t time.Time
 	// Calculate Hour Angle
 	ha := lstRad - raRad
 
-	// Calculate Altitude
-	sinAlt := math.Sin(decRad)*math.Sin(latRad) +
-	          math.Cos(decRad)*math.Cos(latRad)*math.Cos(ha)
-	altitude := math.Asin(sinAlt)
-
-	// Calculate Azimuth
-	cosAz := (math.Sin(decRad) - math.Sin(latRad)*sinAlt) /
-	         (math.Cos(latRad) * math.Cos(altitude))
-
-	// Clamp to prevent NaN from floating point errors
-	cosAz = math.Max(-1.0, math.Min(1.0, cosAz))
-	azimuth := math.Acos(cosAz)
+	// Direction in the horizontal frame (east, north, zenith)
+	east := -math.Cos(decRad) * math.Sin(ha)
+	north := math.Sin(decRad)*math.Cos(latRad) - math.Cos(decRad)*math.Sin(latRad)*math.Cos(ha)
+	up := math.Sin(decRad)*math.Sin(latRad) + math.Cos(decRad)*math.Cos(latRad)*math.Cos(ha)
 
-	// Adjust azimuth quadrant based on hour angle
-	if math.Sin(ha) > 0 {
-		azimuth = 2.0*math.Pi - azimuth
+	altitude := math.Atan2(up, math.Hypot(east, north))
+	azimuth := math.Atan2(east, north)
+	if azimuth < 0 {
+		azimuth += 2.0 * math.Pi
 	}
 
 	return HorizontalCoords{
@@ -65,33 +65,21 @@ func HorizontalToEquatorial(hz HorizontalCoords, observer *Observer, t time.Time
 	azRad := hz.Azimuth * math.Pi / 180.0
 	latRad := observer.Latitude * math.Pi / 180.0
 
-	// Calculate Declination
-	sinDec := math.Sin(altRad)*math.Sin(latRad) +
-	          math.Cos(altRad)*math.Cos(latRad)*math.Cos(azRad)
-	dec := math.Asin(sinDec)
+	// Direction in the equatorial frame of the local meridian (x toward the
+	// meridian on the equator, y toward hour angle 6h)
+	x := math.Sin(altRad)*math.Cos(latRad) - math.Cos(altRad)*math.Sin(latRad)*math.Cos(azRad)
+	y := -math.Cos(altRad) * math.Sin(azRad)
+	z := math.Sin(altRad)*math.Sin(latRad) + math.Cos(altRad)*math.Cos(latRad)*math.Cos(azRad)
 
-	// Calculate Hour Angle
-	cosHA := (math.Sin(altRad) - math.Sin(latRad)*sinDec) /
-	         (math.Cos(latRad) * math.Cos(dec))
-	cosHA = math.Max(-1.0, math.Min(1.0, cosHA))
-	ha := math.Acos(cosHA)
-
-	// Adjust hour angle quadrant based on azimuth
-	if math.Sin(azRad) > 0 {
-		ha = 2.0*math.Pi - ha
-	}
+	dec := math.Atan2(z, math.Hypot(x, y))
+	ha := math.Atan2(y, x)
 
 	// Calculate RA from LST and HA
 	lstRad := lst * 15.0 * math.Pi / 180.0
-	ra := lstRad - ha
-
-	// Normalize RA to 0-2π
-	for ra < 0 {
+	ra := math.Mod(lstRad-ha, 2.0*math.Pi)
+	if ra < 0 {
 		ra += 2.0 * math.Pi
 	}
-	for ra >= 2.0*math.Pi {
-		ra -= 2.0 * math.Pi
-	}
 
 	return EquatorialCoords{
 		RA:  (ra * 180.0 / math.Pi) / 15.0, // Convert to hours
@@ -99,6 +87,34 @@ func HorizontalToEquatorial(hz HorizontalCoords, observer *Observer, t time.Time
 	}
 }
 
+// ParallacticAngle returns the angle in degrees between the directions to
+// the north celestial pole and to the zenith at an object. It is positive
+// west of the meridian, where north on the sky appears tilted clockwise
+// from "up".
+func ParallacticAngle(eq EquatorialCoords, observer *Observer, t time.Time) float64 {
+	lst := observer.LST(t)
+
+	ha := (lst - eq.RA) * 15.0 * math.Pi / 180.0
+	decRad := eq.Dec * math.Pi / 180.0
+	latRad := observer.Latitude * math.Pi / 180.0
+
+	q := math.Atan2(math.Sin(ha), math.Tan(latRad)*math.Cos(decRad)-math.Sin(decRad)*math.Cos(ha))
+	return q * 180.0 / math.Pi
+}
+
+// PrecessToJ2000 converts coordinates referred to the equinox of date t to
+// J2000, the epoch of the bundled catalogs
+func PrecessToJ2000(eq EquatorialCoords, t time.Time) EquatorialCoords {
+	from := &coord.Equatorial{RA: unit.RAFromHour(eq.RA), Dec: unit.AngleFromDeg(eq.Dec)}
+	to := precess.Position(from, &coord.Equatorial{}, base.JDEToJulianYear(JulianDate(t)), 2000, 0, 0)
+
+	ra := math.Mod(to.RA.Hour(), 24)
+	if ra < 0 {
+		ra += 24
+	}
+	return EquatorialCoords{RA: ra, Dec: to.Dec.Deg()}
+}
+
 // FormatRA formats Right Ascension in hours to HH:MM:SS.S format
 func FormatRA(hours float64) string {
 	h := int(hours)
@@ -121,3 +121,20 @@ func TestFormatDec(t *testing.T) {
 		}
 	}
 }
+
+func TestPrecessToJ2000(t *testing.T) {
+	eq := EquatorialCoords{RA: 6, Dec: 0}
+
+	// No change at the J2000 epoch itself
+	same := PrecessToJ2000(eq, time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC))
+	if math.Abs(same.RA-6) > 1e-6 || math.Abs(same.Dec) > 1e-5 {
+		t.Errorf("J2000 to J2000 moved to %+v", same)
+	}
+
+	// On the equator RA precesses about 3.075s per year
+	j2000 := PrecessToJ2000(eq, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	shift := (6 - j2000.RA) * 3600
+	if math.Abs(shift-26*3.075) > 2 {
+		t.Errorf("RA shifted by %.1fs over 26 years, want about %.1fs", shift, 26*3.075)
+	}
+}
@@ -0,0 +1,196 @@
+package astro
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/soniakeys/meeus/v3/julian"
+	"github.com/soniakeys/meeus/v3/moonphase"
+)
+
+// Event is a rise, set, twilight boundary or lunar phase
+type Event int
+
+const (
+	Sunrise Event = iota
+	Sunset
+	CivilDawn
+	CivilDusk
+	NauticalDawn
+	NauticalDusk
+	AstronomicalDawn
+	AstronomicalDusk
+	Moonrise
+	Moonset
+	NewMoon
+	FirstQuarter
+	FullMoon
+	LastQuarter
+)
+
+var eventDisplayNames = [...]string{
+	Sunrise:          "sunrise",
+	Sunset:           "sunset",
+	CivilDawn:        "civil dawn",
+	CivilDusk:        "civil dusk",
+	NauticalDawn:     "nautical dawn",
+	NauticalDusk:     "nautical dusk",
+	AstronomicalDawn: "astronomical dawn",
+	AstronomicalDusk: "astronomical dusk",
+	Moonrise:         "moonrise",
+	Moonset:          "moonset",
+	NewMoon:          "new moon",
+	FirstQuarter:     "first quarter",
+	FullMoon:         "full moon",
+	LastQuarter:      "last quarter",
+}
+
+func (e Event) String() string {
+	return eventDisplayNames[e]
+}
+
+// eventNames maps the names accepted by ParseEvent, with spaces removed
+var eventNames = map[string]Event{
+	"sunrise":          Sunrise,
+	"sunset":           Sunset,
+	"dawn":             CivilDawn,
+	"dusk":             CivilDusk,
+	"civildawn":        CivilDawn,
+	"civildusk":        CivilDusk,
+	"nauticaldawn":     NauticalDawn,
+	"nauticaldusk":     NauticalDusk,
+	"astrodawn":        AstronomicalDawn,
+	"astrodusk":        AstronomicalDusk,
+	"astronomicaldawn": AstronomicalDawn,
+	"astronomicaldusk": AstronomicalDusk,
+	"moonrise":         Moonrise,
+	"moonset":          Moonset,
+	"newmoon":          NewMoon,
+	"firstquarter":     FirstQuarter,
+	"fullmoon":         FullMoon,
+	"lastquarter":      LastQuarter,
+	"thirdquarter":     LastQuarter,
+}
+
+// ParseEvent reads an event name such as "sunset", "astro dusk" or "full
+// moon", ignoring case and spacing. Plain "dawn" and "dusk" are civil.
+func ParseEvent(name string) (Event, bool) {
+	e, ok := eventNames[strings.Join(strings.Fields(strings.ToLower(name)), "")]
+	return e, ok
+}
+
+// horizonEvent is a crossing of an altitude by the Sun or Moon
+type horizonEvent struct {
+	moon     bool
+	altitude float64 // Degrees, for the center of the disk
+	rising   bool
+}
+
+// Rise and set use the standard -0.833°, allowing for refraction and the
+// semidiameter; the Moon's altitude is already topocentric.
+var horizonEvents = map[Event]horizonEvent{
+	Sunrise:          {altitude: -0.833, rising: true},
+	Sunset:           {altitude: -0.833},
+	CivilDawn:        {altitude: -6, rising: true},
+	CivilDusk:        {altitude: -6},
+	NauticalDawn:     {altitude: -12, rising: true},
+	NauticalDusk:     {altitude: -12},
+	AstronomicalDawn: {altitude: -18, rising: true},
+	AstronomicalDusk: {altitude: -18},
+	Moonrise:         {moon: true, altitude: -0.833, rising: true},
+	Moonset:          {moon: true, altitude: -0.833},
+}
+
+// Horizon events are searched in steps of eventStep up to eventWindow away;
+// anything further is treated as not happening (polar day or night)
+const (
+	eventStep   = 10 * time.Minute
+	eventWindow = 3 * 24 * time.Hour
+)
+
+// FindEvent returns the first occurrence of e after t, or the last before it
+// when forward is false
+func FindEvent(e Event, observer *Observer, t time.Time, forward bool) (time.Time, error) {
+	if h, ok := horizonEvents[e]; ok {
+		return findCrossing(h, observer, t, forward, e)
+	}
+	return findPhase(e, t, forward), nil
+}
+
+// findCrossing steps through time until the body crosses the event altitude
+// in the right direction, then bisects to the second
+func findCrossing(h horizonEvent, observer *Observer, t time.Time, forward bool, e Event) (time.Time, error) {
+	// Height above the event altitude, positive once past a rising crossing
+	height := func(at time.Time) float64 {
+		jde := julian.TimeToJD(at)
+		alt := calculateSun(jde, observer, at).Altitude
+		if h.moon {
+			alt = calculateMoon(jde, observer, at).Altitude
+		}
+		if !h.rising {
+			return h.altitude - alt
+		}
+		return alt - h.altitude
+	}
+
+	step := eventStep
+	if !forward {
+		step = -step
+	}
+
+	prev, prevHeight := t, height(t)
+	for elapsed := time.Duration(0); elapsed < eventWindow; elapsed += eventStep {
+		next := prev.Add(step)
+		nextHeight := height(next)
+
+		// Order the pair in time; the event lies where height turns positive
+		early, late, earlyHeight, lateHeight := prev, next, prevHeight, nextHeight
+		if !forward {
+			early, late, earlyHeight, lateHeight = next, prev, nextHeight, prevHeight
+		}
+		if earlyHeight < 0 && lateHeight >= 0 {
+			for late.Sub(early) > time.Second {
+				mid := early.Add(late.Sub(early) / 2)
+				if height(mid) < 0 {
+					early = mid
+				} else {
+					late = mid
+				}
+			}
+			return late.Truncate(time.Second), nil
+		}
+
+		prev, prevHeight = next, nextHeight
+	}
+
+	return time.Time{}, fmt.Errorf("no %s within %.0f days here", e, eventWindow.Hours()/24)
+}
+
+// findPhase returns the nearest lunar phase after or before t
+func findPhase(e Event, t time.Time, forward bool) time.Time {
+	phase := map[Event]func(float64) float64{
+		NewMoon:      moonphase.New,
+		FirstQuarter: moonphase.First,
+		FullMoon:     moonphase.Full,
+		LastQuarter:  moonphase.Last,
+	}[e]
+
+	const synodicYears = 29.530589 / 365.25
+
+	jd := julian.TimeToJD(t)
+	year := float64(t.Year()) + float64(t.YearDay()-1)/365.25
+
+	// moonphase returns the phase nearest the given year, so nudge by a
+	// lunation until it falls on the requested side
+	jde := phase(year)
+	for forward && jde <= jd {
+		year += synodicYears
+		jde = phase(year)
+	}
+	for !forward && jde >= jd {
+		year -= synodicYears
+		jde = phase(year)
+	}
+	return julian.JDToTime(jde).Truncate(time.Second)
+}
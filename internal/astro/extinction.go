@@ -0,0 +1,32 @@
+package astro
+
+import "math"
+
+// extinctionScaleHeight is the height in meters over which extinction
+// falls by a factor of e, dominated by Rayleigh scattering
+const extinctionScaleHeight = 8000.0
+
+// Airmass returns the relative optical path length through the atmosphere
+// for an object at the given altitude in degrees, using the Kasten–Young
+// (1989) formula. Altitudes below the horizon are treated as the horizon.
+func Airmass(altitude float64) float64 {
+	h := math.Max(0, altitude)
+	return 1.0 / (math.Sin(h*math.Pi/180.0) + 0.50572*math.Pow(h+6.07995, -1.6364))
+}
+
+// Extinction returns the dimming in magnitudes for an object at the given
+// altitude. coefficient is the zenith extinction at sea level in magnitudes
+// per airmass; it is reduced for sites at higher elevation (meters).
+func Extinction(altitude, coefficient, elevation float64) float64 {
+	if coefficient <= 0 {
+		return 0
+	}
+	k := coefficient * math.Exp(-math.Max(0, elevation)/extinctionScaleHeight)
+	return k * Airmass(altitude)
+}
+
+// ExtinctionAt returns the extinction in magnitudes at the given altitude
+// for this observer's site
+func (o *Observer) ExtinctionAt(altitude float64) float64 {
+	return Extinction(altitude, o.ExtinctionCoefficient, o.Altitude)
+}
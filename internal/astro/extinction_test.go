@@ -0,0 +1,49 @@
+package astro
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAirmass(t *testing.T) {
+	tests := []struct {
+		altitude float64
+		want     float64
+		tol      float64
+	}{
+		{90, 1.0, 0.001},
+		{30, 1.995, 0.01},
+		{10, 5.60, 0.05},
+		{0, 37.92, 0.1},
+	}
+
+	for _, tt := range tests {
+		got := Airmass(tt.altitude)
+		if math.Abs(got-tt.want) > tt.tol {
+			t.Errorf("Airmass(%.0f) = %.3f, want %.3f", tt.altitude, got, tt.want)
+		}
+	}
+
+	if Airmass(-5) != Airmass(0) {
+		t.Error("altitudes below the horizon should use the horizon airmass")
+	}
+}
+
+func TestExtinction(t *testing.T) {
+	zenith := Extinction(90, 0.2, 0)
+	if math.Abs(zenith-0.2) > 0.001 {
+		t.Errorf("zenith extinction = %.3f, want the coefficient 0.2", zenith)
+	}
+
+	if low := Extinction(10, 0.2, 0); low <= zenith {
+		t.Errorf("extinction near the horizon (%.2f) should exceed zenith (%.2f)", low, zenith)
+	}
+
+	if high := Extinction(90, 0.2, 4000); high >= zenith {
+		t.Errorf("a mountain site (%.3f) should see less extinction than sea level (%.3f)", high, zenith)
+	}
+
+	if Extinction(30, 0, 0) != 0 {
+		t.Error("zero coefficient should disable extinction")
+	}
+}
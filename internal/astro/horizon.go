@@ -0,0 +1,70 @@
+package astro
+
+import (
+	"math"
+	"slices"
+)
+
+// HorizonPoint is the altitude of the local horizon in one direction
+type HorizonPoint struct {
+	Azimuth  float64 // Degrees
+	Altitude float64 // Degrees
+}
+
+// HorizonProfile outlines trees, buildings or hills around a site. Between
+// points the altitude is interpolated linearly, wrapping through north.
+type HorizonProfile []HorizonPoint
+
+// NewHorizonProfile returns a profile with azimuths normalized to 0-360 and
+// sorted
+func NewHorizonProfile(points []HorizonPoint) HorizonProfile {
+	profile := make(HorizonProfile, len(points))
+	for i, p := range points {
+		profile[i] = HorizonPoint{Azimuth: math.Mod(math.Mod(p.Azimuth, 360)+360, 360), Altitude: p.Altitude}
+	}
+	slices.SortFunc(profile, func(a, b HorizonPoint) int {
+		switch {
+		case a.Azimuth < b.Azimuth:
+			return -1
+		case a.Azimuth > b.Azimuth:
+			return 1
+		}
+		return 0
+	})
+	return profile
+}
+
+// AltitudeAt returns the horizon altitude at an azimuth, 0 for an empty
+// profile
+func (h HorizonProfile) AltitudeAt(az float64) float64 {
+	switch len(h) {
+	case 0:
+		return 0
+	case 1:
+		return h[0].Altitude
+	}
+
+	az = math.Mod(math.Mod(az, 360)+360, 360)
+
+	// Find the points either side, wrapping past the last to the first
+	i, _ := slices.BinarySearchFunc(h, az, func(p HorizonPoint, az float64) int {
+		switch {
+		case p.Azimuth < az:
+			return -1
+		case p.Azimuth > az:
+			return 1
+		}
+		return 0
+	})
+	if i < len(h) && h[i].Azimuth == az {
+		return h[i].Altitude
+	}
+	prev, next := h[(i-1+len(h))%len(h)], h[i%len(h)]
+
+	span := math.Mod(next.Azimuth-prev.Azimuth+360, 360)
+	if span == 0 {
+		return prev.Altitude
+	}
+	f := math.Mod(az-prev.Azimuth+360, 360) / span
+	return prev.Altitude + f*(next.Altitude-prev.Altitude)
+}
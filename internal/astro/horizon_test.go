@@ -0,0 +1,42 @@
+package astro
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHorizonProfileAltitudeAt(t *testing.T) {
+	profile := NewHorizonProfile([]HorizonPoint{
+		{Azimuth: 90, Altitude: 10},
+		{Azimuth: 350, Altitude: 20},
+		{Azimuth: 180, Altitude: 0},
+		{Azimuth: -90, Altitude: 5}, // 270
+	})
+
+	tests := []struct {
+		az, want float64
+	}{
+		{90, 10},
+		{135, 5},
+		{270, 5},
+		{310, 12.5},
+		{350, 20},
+		// Wrapping through north from 350 to 90
+		{0, 19},
+		{40, 15},
+		{400, 15},
+		{-10, 20},
+	}
+	for _, tt := range tests {
+		if got := profile.AltitudeAt(tt.az); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("AltitudeAt(%g) = %g, want %g", tt.az, got, tt.want)
+		}
+	}
+
+	if got := HorizonProfile(nil).AltitudeAt(123); got != 0 {
+		t.Errorf("empty profile = %g, want 0", got)
+	}
+	if got := NewHorizonProfile([]HorizonPoint{{Azimuth: 10, Altitude: 7}}).AltitudeAt(200); got != 7 {
+		t.Errorf("single point = %g, want 7", got)
+	}
+}
@@ -8,6 +8,15 @@ type Observer struct {
 	Longitude float64 // Degrees, positive East
 	Altitude  float64 // Meters above sea level
 	Name      string  // Location name
+
+	// Zenith extinction at sea level in magnitudes per airmass, 0 disables
+	ExtinctionCoefficient float64
+
+	// Local obstructions; nil for a flat horizon
+	Horizon HorizonProfile
+
+	// Time zone at the site; nil when unknown
+	Zone *time.Location
 }
 
 // NewObserver creates a new observer at the given location
@@ -20,6 +29,14 @@ func NewObserver(lat, lon, alt float64, name string) *Observer {
 	}
 }
 
+// LocalZone returns the site's time zone, or the machine's when unknown
+func (o *Observer) LocalZone() *time.Location {
+	if o.Zone == nil {
+		return time.Local
+	}
+	return o.Zone
+}
+
 // LST returns the Local Sidereal Time for this observer at the given time
 func (o *Observer) LST(t time.Time) float64 {
 	jd := JulianDate(t)
@@ -4,6 +4,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/soniakeys/meeus/v3/base"
 	"github.com/soniakeys/meeus/v3/coord"
 	"github.com/soniakeys/meeus/v3/julian"
 	"github.com/soniakeys/meeus/v3/moonposition"
@@ -12,6 +13,12 @@ import (
 	"github.com/soniakeys/unit"
 )
 
+const (
+	earthRadiusKm  = 6378.14
+	moonRadiusKm   = 1737.4
+	sunSemidiamArc = 959.63 // Sun's semidiameter in arcseconds at 1 AU
+)
+
 // BodyType represents the type of celestial body
 type BodyType string
 
@@ -30,6 +37,8 @@ type Planet struct {
 	Altitude  float64  // Calculated altitude
 	Azimuth   float64  // Calculated azimuth
 	Magnitude float64  // Visual magnitude
+
+	AngularDiameter float64 // Apparent diameter in degrees, zero when not computed
 }
 
 // PlanetarySystem holds all planets and the Moon
@@ -82,6 +91,8 @@ func calculateSun(jde float64, observer *Observer, t time.Time) Planet {
 	eq := EquatorialCoords{RA: raHours, Dec: decDeg}
 	hz := EquatorialToHorizontal(eq, observer, t)
 
+	distance := solar.Radius(base.J2000Century(jde))
+
 	return Planet{
 		Name:      "Sun",
 		BodyType:  BodyTypeSun,
@@ -90,22 +101,34 @@ func calculateSun(jde float64, observer *Observer, t time.Time) Planet {
 		Altitude:  hz.Altitude,
 		Azimuth:   hz.Azimuth,
 		Magnitude: -26.7, // Sun's apparent magnitude
+
+		AngularDiameter: 2 * sunSemidiamArc / distance / 3600.0,
 	}
 }
 
 // calculateMoon computes Moon position
 func calculateMoon(jde float64, observer *Observer, t time.Time) Planet {
-	// Moon position
-	ra, dec, _ := moonposition.Position(jde)
+	ra, dec, distance := moonEquatorial(jde)
 
 	// Convert to hours and degrees
-	raHours := ra.Rad() * 12.0 / math.Pi // RA radians to hours
+	raHours := ra.Hour()
 	decDeg := dec.Deg()
 
 	// Convert to horizontal coordinates
 	eq := EquatorialCoords{RA: raHours, Dec: decDeg}
 	hz := EquatorialToHorizontal(eq, observer, t)
 
+	// The Moon is close enough that the observer's offset from Earth's
+	// center shifts it by up to a degree (horizontal parallax)
+	altRad := hz.Altitude * math.Pi / 180.0
+	parallax := math.Asin(earthRadiusKm / distance * math.Cos(altRad))
+	hz.Altitude -= parallax * 180.0 / math.Pi
+	topocentric := distance - earthRadiusKm*math.Sin(altRad)
+
+	// Report the RA/Dec the observer sees, so anything fixed in RA/Dec
+	// (such as a trail) lines up with the drawn Moon
+	eq = HorizontalToEquatorial(hz, observer, t)
+
 	// Approximate magnitude based on phase
 	// Moon varies from about -12.7 (full) to much dimmer
 	mag := -12.0
@@ -113,14 +136,30 @@ func calculateMoon(jde float64, observer *Observer, t time.Time) Planet {
 	return Planet{
 		Name:      "Moon",
 		BodyType:  BodyTypeMoon,
-		RA:        raHours,
-		Dec:       decDeg,
+		RA:        eq.RA,
+		Dec:       eq.Dec,
 		Altitude:  hz.Altitude,
 		Azimuth:   hz.Azimuth,
 		Magnitude: mag,
+
+		AngularDiameter: 2 * math.Asin(moonRadiusKm/topocentric) * 180.0 / math.Pi,
 	}
 }
 
+// moonEquatorial returns the Moon's apparent geocentric RA/Dec and its
+// distance in kilometers. moonposition works in ecliptic coordinates, so
+// nutation is applied and the result rotated by the true obliquity.
+func moonEquatorial(jde float64) (unit.RA, unit.Angle, float64) {
+	lon, lat, distance := moonposition.Position(jde)
+
+	Δψ, Δε := nutation.Nutation(jde)
+	ε := nutation.MeanObliquity(jde) + Δε
+	sε, cε := ε.Sincos()
+
+	ra, dec := coord.EclToEq(lon+Δψ, lat, sε, cε)
+	return ra, dec, distance
+}
+
 // calculatePlanetLowPrecision computes approximate planet position
 // Uses simplified orbital elements - accurate to ~1 arcminute for dates near 2000
 func calculatePlanetLowPrecision(name string, jde float64, observer *Observer, t time.Time) Planet {
@@ -280,7 +319,7 @@ func MoonPhase(t time.Time) float64 {
 
 	// Get Sun and Moon positions
 	sunRA, sunDec := solar.ApparentEquatorial(jde)
-	moonRA, moonDec, _ := moonposition.Position(jde)
+	moonRA, moonDec, _ := moonEquatorial(jde)
 
 	// Convert to radians
 	sunRARad := sunRA.Rad()
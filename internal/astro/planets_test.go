@@ -0,0 +1,39 @@
+package astro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/soniakeys/meeus/v3/julian"
+)
+
+func TestMoonNearSunAtNewMoon(t *testing.T) {
+	// New Moon of 2025-01-29 12:36 UTC
+	now := time.Date(2025, 1, 29, 12, 36, 0, 0, time.UTC)
+	jde := julian.TimeToJD(now)
+	observer := DefaultObserver()
+
+	sun := calculateSun(jde, observer, now)
+	moon := calculateMoon(jde, observer, now)
+
+	// Same spherical geometry as alt/az, with Dec and RA in degrees
+	sep := AngularSeparation(sun.Dec, sun.RA*15, moon.Dec, moon.RA*15)
+
+	// Conjunction in longitude; only the Moon's ecliptic latitude separates them
+	if sep > 6 {
+		t.Errorf("Sun-Moon separation at new moon = %.2f°, want < 6°", sep)
+	}
+}
+
+func TestAngularDiameters(t *testing.T) {
+	now := time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC)
+	ps := CalculatePlanets(now, DefaultObserver())
+
+	// Aphelion: the Sun is at its smallest
+	if ps.Sun.AngularDiameter < 0.524 || ps.Sun.AngularDiameter > 0.527 {
+		t.Errorf("Sun diameter = %.4f°, want about 0.525°", ps.Sun.AngularDiameter)
+	}
+	if ps.Moon.AngularDiameter < 0.49 || ps.Moon.AngularDiameter > 0.57 {
+		t.Errorf("Moon diameter = %.4f°, want between 0.49° and 0.57°", ps.Moon.AngularDiameter)
+	}
+}
@@ -0,0 +1,129 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// Twilight identifies the phase of the day from the Sun's altitude
+type Twilight int
+
+const (
+	Day Twilight = iota
+	CivilTwilight
+	NauticalTwilight
+	AstronomicalTwilight
+	Night
+)
+
+// String returns a display name for the twilight phase
+func (tw Twilight) String() string {
+	switch tw {
+	case Day:
+		return "Day"
+	case CivilTwilight:
+		return "Civil twilight"
+	case NauticalTwilight:
+		return "Nautical twilight"
+	case AstronomicalTwilight:
+		return "Astronomical twilight"
+	default:
+		return "Night"
+	}
+}
+
+// Limiting magnitudes at the boundaries of each twilight phase
+const (
+	dayLimitingMagnitude      = -3.5 // Only the Sun, Moon and Venus
+	civilLimitingMagnitude    = 2.0  // Brightest stars at the end of civil twilight
+	nauticalLimitingMagnitude = 4.5  // Most constellations at the end of nautical twilight
+)
+
+// SkyConditions describes how bright the sky is for an observer at a moment
+type SkyConditions struct {
+	SunAltitude      float64 // Degrees
+	MoonAltitude     float64 // Degrees
+	MoonAzimuth      float64 // Degrees
+	MoonIllumination float64 // Illuminated fraction, 0 (new) to 1 (full)
+}
+
+// NewSkyConditions derives sky conditions from computed Sun and Moon positions
+func NewSkyConditions(planets *PlanetarySystem, t time.Time) SkyConditions {
+	elongation := MoonPhase(t) * math.Pi
+
+	return SkyConditions{
+		SunAltitude:      planets.Sun.Altitude,
+		MoonAltitude:     planets.Moon.Altitude,
+		MoonAzimuth:      planets.Moon.Azimuth,
+		MoonIllumination: (1 - math.Cos(elongation)) / 2,
+	}
+}
+
+// Twilight returns the current twilight phase
+func (s SkyConditions) Twilight() Twilight {
+	switch {
+	case s.SunAltitude >= -0.833:
+		return Day
+	case s.SunAltitude >= -6:
+		return CivilTwilight
+	case s.SunAltitude >= -12:
+		return NauticalTwilight
+	case s.SunAltitude >= -18:
+		return AstronomicalTwilight
+	default:
+		return Night
+	}
+}
+
+// LimitingMagnitude returns the faintest magnitude visible at the given
+// position, starting from darkLimit under a moonless night sky and reduced
+// by twilight and by moonlight scattered near the Moon
+func (s SkyConditions) LimitingMagnitude(darkLimit, alt, az float64) float64 {
+	limit := darkLimit
+
+	// Sunlight: interpolate between phase boundaries
+	sun := s.SunAltitude
+	switch {
+	case sun >= 5:
+		limit = dayLimitingMagnitude
+	case sun >= -6:
+		limit = lerp(dayLimitingMagnitude, civilLimitingMagnitude, (5-sun)/11)
+	case sun >= -12:
+		limit = lerp(civilLimitingMagnitude, nauticalLimitingMagnitude, (-6-sun)/6)
+	case sun >= -18:
+		limit = lerp(nauticalLimitingMagnitude, darkLimit, (-12-sun)/6)
+	}
+	limit = math.Min(limit, darkLimit)
+
+	// Moonlight: a general brightening of the whole sky plus glare that
+	// grows steeply close to the Moon
+	if s.MoonAltitude > 0 && s.MoonIllumination > 0 {
+		height := math.Min(1, s.MoonAltitude/20)
+		sep := AngularSeparation(alt, az, s.MoonAltitude, s.MoonAzimuth)
+		glare := 1.5 + 3.0*math.Exp(-sep/10)
+		limit -= s.MoonIllumination * height * glare
+	}
+
+	return limit
+}
+
+// AngularSeparation returns the angle in degrees between two horizontal positions
+func AngularSeparation(alt1, az1, alt2, az2 float64) float64 {
+	a1 := alt1 * math.Pi / 180
+	a2 := alt2 * math.Pi / 180
+	dAz := (az2 - az1) * math.Pi / 180
+
+	// Vincenty formula, stable for both small and large angles
+	num := math.Hypot(
+		math.Cos(a2)*math.Sin(dAz),
+		math.Cos(a1)*math.Sin(a2)-math.Sin(a1)*math.Cos(a2)*math.Cos(dAz),
+	)
+	den := math.Sin(a1)*math.Sin(a2) + math.Cos(a1)*math.Cos(a2)*math.Cos(dAz)
+
+	return math.Atan2(num, den) * 180 / math.Pi
+}
+
+func lerp(a, b, f float64) float64 {
+	f = math.Max(0, math.Min(1, f))
+	return a + (b-a)*f
+}
@@ -0,0 +1,75 @@
+package astro
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTwilightPhases(t *testing.T) {
+	tests := []struct {
+		sunAlt float64
+		want   Twilight
+	}{
+		{30, Day},
+		{-3, CivilTwilight},
+		{-9, NauticalTwilight},
+		{-15, AstronomicalTwilight},
+		{-40, Night},
+	}
+
+	for _, tt := range tests {
+		got := SkyConditions{SunAltitude: tt.sunAlt}.Twilight()
+		if got != tt.want {
+			t.Errorf("sun at %.0f°: got %v, want %v", tt.sunAlt, got, tt.want)
+		}
+	}
+}
+
+func TestLimitingMagnitudeFollowsSun(t *testing.T) {
+	previous := math.Inf(-1)
+	for sunAlt := 30.0; sunAlt >= -30; sunAlt -= 3 {
+		limit := SkyConditions{SunAltitude: sunAlt, MoonAltitude: -10}.LimitingMagnitude(6, 45, 180)
+		if limit < previous {
+			t.Errorf("limit decreased as the Sun set: %.2f at %.0f° after %.2f", limit, sunAlt, previous)
+		}
+		previous = limit
+	}
+
+	day := SkyConditions{SunAltitude: 40}.LimitingMagnitude(6, 45, 180)
+	if day < -4.4 || day > -2.7 {
+		t.Errorf("daytime limit %.2f should show Venus (-4.4) but not Jupiter (-2.7)", day)
+	}
+
+	night := SkyConditions{SunAltitude: -40, MoonAltitude: -10}.LimitingMagnitude(6, 45, 180)
+	if night != 6 {
+		t.Errorf("moonless night limit = %.2f, want 6", night)
+	}
+}
+
+func TestMoonGlare(t *testing.T) {
+	sky := SkyConditions{SunAltitude: -40, MoonAltitude: 40, MoonAzimuth: 180, MoonIllumination: 1}
+
+	near := sky.LimitingMagnitude(6, 42, 180)
+	far := sky.LimitingMagnitude(6, 40, 0)
+	if near >= far {
+		t.Errorf("limit near the Moon (%.2f) should be brighter than far away (%.2f)", near, far)
+	}
+	if far >= 6 {
+		t.Errorf("a full Moon should brighten the whole sky, got %.2f", far)
+	}
+
+	newMoon := sky
+	newMoon.MoonIllumination = 0
+	if got := newMoon.LimitingMagnitude(6, 42, 180); got != 6 {
+		t.Errorf("new Moon should not affect the limit, got %.2f", got)
+	}
+}
+
+func TestAngularSeparation(t *testing.T) {
+	if got := AngularSeparation(0, 0, 0, 90); math.Abs(got-90) > 1e-9 {
+		t.Errorf("got %.6f, want 90", got)
+	}
+	if got := AngularSeparation(89.9, 0, 89.9, 180); math.Abs(got-0.2) > 1e-6 {
+		t.Errorf("got %.6f, want 0.2 across the zenith", got)
+	}
+}
@@ -15,7 +15,7 @@ func JulianDate(t time.Time) float64 {
 	day := t.Day()
 	hour := t.Hour()
 	minute := t.Minute()
-	second := t.Second()
+	second := float64(t.Second()) + float64(t.Nanosecond())/1e9
 
 	// Adjust for January and February
 	if month <= 2 {
@@ -24,7 +24,7 @@ func JulianDate(t time.Time) float64 {
 	}
 
 	// Calculate day fraction
-	dayFraction := float64(day) + float64(hour)/24.0 + float64(minute)/1440.0 + float64(second)/86400.0
+	dayFraction := float64(day) + float64(hour)/24.0 + float64(minute)/1440.0 + second/86400.0
 
 	a := year / 100
 	b := 2 - a + a/4
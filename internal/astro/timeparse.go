@@ -0,0 +1,276 @@
+package astro
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeContext is what a typed time is read against
+type TimeContext struct {
+	Now      time.Time      // Real time, for "now"
+	Current  time.Time      // Simulated time that offsets, days and events count from
+	Location *time.Location // Zone for times written without one
+	Observer *Observer      // Site for rise, set and twilight events
+}
+
+// Layouts for absolute dates and times, tried in order
+var (
+	dateLayouts  = []string{"2006-01-02"}
+	clockLayouts = []string{"15:04:05", "15:04"}
+)
+
+var (
+	offsetPattern = regexp.MustCompile(`^([+-])((?:\d+(?:\.\d+)?[a-z]+)+)$`)
+	offsetPart    = regexp.MustCompile(`(\d+(?:\.\d+)?)([a-z]+)`)
+	zoneOffset    = regexp.MustCompile(`^(?:utc|gmt)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+)
+
+// relativeDays are the day keywords and their offsets from the current day
+var relativeDays = map[string]int{"yesterday": -1, "today": 0, "tomorrow": 1}
+
+// offsetUnits are the units accepted in relative offsets
+var offsetUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second,
+	"m": time.Minute, "min": time.Minute,
+	"h": time.Hour, "hr": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour,
+}
+
+// ParseTime reads a typed time. It accepts
+//   - absolute times: "2026-08-12", "2026-08-12 22:00[:00]", "22:00"
+//     (today), optionally followed by a zone ("UTC", "America/Denver",
+//     "+02:00"); without one the context's location is used
+//   - "now", "today", "tomorrow" or "yesterday", with an optional time
+//   - offsets from the current time: "+2h", "-3d", "+1d6h30m"
+//   - Julian dates: "JD 2461000.5" or "MJD 61000"
+//   - events, optionally with "next" or "previous": "sunset", "astro dusk",
+//     "moonrise", "next full moon"
+func ParseTime(input string, ctx TimeContext) (time.Time, error) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return time.Time{}, fmt.Errorf("enter a time")
+	}
+	lower := strings.ToLower(strings.Join(fields, " "))
+
+	switch {
+	case lower == "now":
+		return ctx.Now, nil
+	case offsetPattern.MatchString(lower):
+		return parseOffset(lower, ctx.Current)
+	case strings.HasPrefix(lower, "jd") || strings.HasPrefix(lower, "mjd"):
+		return parseJulian(lower)
+	}
+
+	if t, ok, err := parseEventTime(lower, ctx); ok {
+		return t, err
+	}
+
+	return parseAbsolute(fields, ctx)
+}
+
+// parseOffset adds an offset such as "+1d6h" to t
+func parseOffset(s string, t time.Time) (time.Time, error) {
+	m := offsetPattern.FindStringSubmatch(s)
+	var seconds float64
+	for _, part := range offsetPart.FindAllStringSubmatch(m[2], -1) {
+		n, err := strconv.ParseFloat(part[1], 64)
+		unit, ok := offsetUnits[part[2]]
+		if err != nil || !ok {
+			return time.Time{}, fmt.Errorf("can't read offset %q; use units s, m, h, d or w", s)
+		}
+		seconds += n * unit.Seconds()
+	}
+	if m[1] == "-" {
+		seconds = -seconds
+	}
+	t, ok := addSeconds(t, seconds)
+	if !ok {
+		return time.Time{}, fmt.Errorf("offset %q goes past the years %d to %d", s, minYear, maxYear)
+	}
+	return t, nil
+}
+
+// parseJulian reads "JD n" or "MJD n"
+func parseJulian(s string) (time.Time, error) {
+	prefix, number := "jd", strings.TrimPrefix(s, "jd")
+	if strings.HasPrefix(s, "mjd") {
+		prefix, number = "mjd", strings.TrimPrefix(s, "mjd")
+	}
+	jd, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't read %q; use e.g. JD 2461000.5 or MJD 61000", s)
+	}
+	if prefix == "mjd" {
+		jd += 2400000.5
+	}
+	t, ok := julianDateToTime(jd)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%q is outside the years %d to %d", s, minYear, maxYear)
+	}
+	return t, nil
+}
+
+// JulianDateToTime converts a Julian Date to UTC. Dates outside the years
+// minYear to maxYear give the zero time.
+func JulianDateToTime(jd float64) time.Time {
+	t, ok := julianDateToTime(jd)
+	if !ok {
+		return time.Time{}
+	}
+	return t
+}
+
+// julianDateToTime converts a Julian Date to UTC, reporting false when it
+// lies outside the years minYear to maxYear
+func julianDateToTime(jd float64) (time.Time, bool) {
+	j2000 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	t, ok := addSeconds(j2000, (jd-2451545.0)*secondsPerDay)
+	return t.Round(time.Millisecond), ok
+}
+
+// Typed times must fall within these years
+const (
+	minYear = 1
+	maxYear = 9999
+)
+
+// secondsPerDay is the length of a day in seconds
+const secondsPerDay = 86400
+
+// addSeconds adds a number of seconds to t. Whole days are added by date so
+// spans past time.Duration's ±292 years don't overflow. It reports false
+// when the result falls outside minYear to maxYear.
+func addSeconds(t time.Time, seconds float64) (time.Time, bool) {
+	if math.IsNaN(seconds) || math.Abs(seconds) > 366*secondsPerDay*(maxYear-minYear+1) {
+		return time.Time{}, false
+	}
+	days := math.Trunc(seconds / secondsPerDay)
+	rest := time.Duration(math.Round((seconds - days*secondsPerDay) * float64(time.Second)))
+	// Count days in UTC so each one is exactly 24 hours, even across DST
+	t = t.UTC().AddDate(0, 0, int(days)).Add(rest).In(t.Location())
+	return t, t.Year() >= minYear && t.Year() <= maxYear
+}
+
+// parseEventTime reads an event name with an optional "next" or "previous".
+// ok reports whether the input named an event at all.
+func parseEventTime(s string, ctx TimeContext) (t time.Time, ok bool, err error) {
+	// Try the whole input first so "last quarter" keeps its "last"
+	e, found := ParseEvent(s)
+	forward := true
+	for _, prefix := range []string{"next ", "previous ", "prev ", "last "} {
+		if found {
+			break
+		}
+		if rest, cut := strings.CutPrefix(s, prefix); cut {
+			e, found = ParseEvent(rest)
+			forward = prefix == "next "
+		}
+	}
+	if !found {
+		return time.Time{}, false, nil
+	}
+
+	if ctx.Observer == nil {
+		return time.Time{}, true, fmt.Errorf("no observer for %s", e)
+	}
+	t, err = FindEvent(e, ctx.Observer, ctx.Current, forward)
+	return t, true, err
+}
+
+// parseAbsolute reads a date and/or clock time with an optional trailing zone
+func parseAbsolute(fields []string, ctx TimeContext) (time.Time, error) {
+	loc := ctx.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	input := strings.Join(fields, " ")
+
+	// A trailing zone applies to the rest
+	if len(fields) > 1 {
+		if zone, ok := parseZone(fields[len(fields)-1]); ok {
+			loc = zone
+			fields = fields[:len(fields)-1]
+		}
+	}
+
+	// "T" between date and time, as in ISO 8601
+	if len(fields) == 1 {
+		if date, clock, ok := strings.Cut(fields[0], "T"); ok && len(date) == 10 {
+			fields = []string{date, clock}
+		}
+	}
+
+	current := ctx.Current.In(loc)
+	day := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, loc)
+
+	// Relative days keep the current time of day unless one is given;
+	// a written date without a time means midnight
+	dayShift, relative := relativeDays[strings.ToLower(fields[0])]
+	dated := relative
+	if relative {
+		day = day.AddDate(0, 0, dayShift)
+	} else {
+		for _, layout := range dateLayouts {
+			if d, err := time.ParseInLocation(layout, fields[0], loc); err == nil {
+				day, dated = d, true
+				break
+			}
+		}
+	}
+	if dated {
+		fields = fields[1:]
+	}
+
+	switch len(fields) {
+	case 0:
+		if relative {
+			return time.Date(day.Year(), day.Month(), day.Day(), current.Hour(), current.Minute(), current.Second(), 0, loc), nil
+		}
+		if dated {
+			return day, nil
+		}
+	case 1:
+		for _, layout := range clockLayouts {
+			if c, err := time.Parse(layout, fields[0]); err == nil {
+				return time.Date(day.Year(), day.Month(), day.Day(), c.Hour(), c.Minute(), c.Second(), 0, loc), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("can't read %q; try 2026-08-12 22:00, +2h, tomorrow 21:30, JD 2461000.5 or sunset", input)
+}
+
+// parseZone reads a zone name ("UTC", "America/Denver") or a UTC offset
+// ("+02:00", "-0700", "UTC+5")
+func parseZone(s string) (*time.Location, bool) {
+	lower := strings.ToLower(s)
+	switch lower {
+	case "utc", "gmt", "z":
+		return time.UTC, true
+	case "local":
+		return time.Local, true
+	}
+
+	if m := zoneOffset.FindStringSubmatch(lower); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		offset := hours*3600 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(strings.ToUpper(s), offset), true
+	}
+
+	// IANA names have a slash; bare abbreviations like "MST" are ambiguous
+	if strings.Contains(s, "/") {
+		if loc, err := time.LoadLocation(s); err == nil {
+			return loc, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,141 @@
+package astro
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func testTimeContext() TimeContext {
+	return TimeContext{
+		Now:      time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		Current:  time.Date(2026, 8, 12, 20, 0, 0, 0, time.UTC),
+		Location: time.FixedZone("EST", -5*3600),
+		Observer: NewObserver(51.5074, -0.1278, 11, "London"),
+	}
+}
+
+func TestParseTimeAbsolute(t *testing.T) {
+	ctx := testTimeContext()
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		// Without a zone, times are in the context's location
+		{"2026-08-12 22:00", time.Date(2026, 8, 13, 3, 0, 0, 0, time.UTC)},
+		{"2026-08-12 22:00:30", time.Date(2026, 8, 13, 3, 0, 30, 0, time.UTC)},
+		{"2026-08-12", time.Date(2026, 8, 12, 5, 0, 0, 0, time.UTC)},
+		{"2026-08-12T22:00", time.Date(2026, 8, 13, 3, 0, 0, 0, time.UTC)},
+		{"2026-08-12 22:00 UTC", time.Date(2026, 8, 12, 22, 0, 0, 0, time.UTC)},
+		{"2026-08-12 22:00 America/Denver", time.Date(2026, 8, 13, 4, 0, 0, 0, time.UTC)},
+		{"2026-08-12 22:00 +02:00", time.Date(2026, 8, 12, 20, 0, 0, 0, time.UTC)},
+		{"2026-08-12 22:00 -0700", time.Date(2026, 8, 13, 5, 0, 0, 0, time.UTC)},
+
+		// Current is 15:00 on the 12th in EST
+		{"21:30", time.Date(2026, 8, 13, 2, 30, 0, 0, time.UTC)},
+		{"tomorrow 21:30", time.Date(2026, 8, 14, 2, 30, 0, 0, time.UTC)},
+		{"Yesterday", time.Date(2026, 8, 11, 20, 0, 0, 0, time.UTC)},
+		{"now", ctx.Now},
+
+		{"+2h", ctx.Current.Add(2 * time.Hour)},
+		{"-3d", ctx.Current.Add(-72 * time.Hour)},
+		{"+1d6h30m", ctx.Current.Add(30*time.Hour + 30*time.Minute)},
+		{"+1.5w", ctx.Current.Add(252 * time.Hour)},
+
+		{"JD 2451545.0", time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"jd2460000.5", time.Date(2023, 2, 25, 0, 0, 0, 0, time.UTC)},
+		{"MJD 51544.5", time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)},
+
+		// Past time.Duration's ±292 years
+		{"JD 2000000", time.Date(763, 9, 18, 12, 0, 0, 0, time.UTC)},
+		{"MJD 0", time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC)},
+		{"JD 5000000.25", time.Date(8977, 6, 7, 18, 0, 0, 0, time.UTC)},
+		{"+200000d", ctx.Current.UTC().AddDate(0, 0, 200000)},
+		{"-1000w", ctx.Current.UTC().AddDate(0, 0, -7000)},
+		{"+100000d12h", ctx.Current.UTC().AddDate(0, 0, 100000).Add(12 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTime(tt.input, ctx)
+		if err != nil {
+			t.Errorf("%q: %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%q = %v, want %v", tt.input, got.UTC(), tt.want)
+		}
+	}
+}
+
+func TestParseTimeErrors(t *testing.T) {
+	for _, input := range []string{
+		"", "soon", "2026-13-01", "+2x", "JD abc", "2026-08-12 25:00", "2026-08-12 22:00 Mars/Olympus",
+		"JD 0", "JD 99999999", "MJD -1000000", "JD 1e300", "+3000000d", "-800000d", "+99999999999999999999w",
+	} {
+		if got, err := ParseTime(input, testTimeContext()); err == nil {
+			t.Errorf("%q: want an error, got %v", input, got)
+		}
+	}
+}
+
+func TestParseTimeEvents(t *testing.T) {
+	ctx := testTimeContext()
+
+	// Sunset in London on the solstice is about 20:21 UTC
+	ctx.Current = time.Date(2026, 6, 21, 12, 0, 0, 0, time.UTC)
+	sunset, err := ParseTime("sunset", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2026, 6, 21, 20, 21, 0, 0, time.UTC)
+	if d := sunset.Sub(want); d < -3*time.Minute || d > 3*time.Minute {
+		t.Errorf("sunset = %v, want about %v", sunset, want)
+	}
+
+	// Previous sunset is the day before; astronomical dusk never comes in
+	// London around the solstice
+	prev, err := ParseTime("previous sunset", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := sunset.Sub(prev); d < 23*time.Hour || d > 25*time.Hour {
+		t.Errorf("previous sunset %v is not a day before %v", prev, sunset)
+	}
+	if _, err := ParseTime("astro dusk", ctx); err == nil {
+		t.Errorf("astro dusk: want an error in midsummer London")
+	}
+
+	// The Sun sets after dusk starts and before it ends
+	nautical, err := ParseTime("nautical dusk", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !nautical.After(sunset) {
+		t.Errorf("nautical dusk %v is not after sunset %v", nautical, sunset)
+	}
+
+	// Full moon of 2026-08-28 04:18 UTC
+	ctx.Current = time.Date(2026, 8, 12, 0, 0, 0, 0, time.UTC)
+	full, err := ParseTime("next full moon", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = time.Date(2026, 8, 28, 4, 18, 0, 0, time.UTC)
+	if d := full.Sub(want); d < -5*time.Minute || d > 5*time.Minute {
+		t.Errorf("next full moon = %v, want about %v", full, want)
+	}
+	if last, err := ParseTime("last quarter", ctx); err != nil || !last.After(ctx.Current) {
+		t.Errorf("last quarter = %v, %v; want the next one", last, err)
+	}
+	if prev, err := ParseTime("last full moon", ctx); err != nil || !prev.Before(ctx.Current) {
+		t.Errorf("last full moon = %v, %v; want the previous one", prev, err)
+	}
+
+	moonrise, err := ParseTime("moonrise", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := moonrise.Sub(ctx.Current); d <= 0 || d > 26*time.Hour {
+		t.Errorf("moonrise %v is not within a day of %v", moonrise, ctx.Current)
+	}
+}
@@ -0,0 +1,79 @@
+package astro
+
+import "time"
+
+// TrailPoint is one sample of a body's path. RA/Dec are fixed when the
+// trail is built so the path stays put against the stars.
+type TrailPoint struct {
+	Time     time.Time
+	RA       float64 // Hours
+	Dec      float64 // Degrees
+	Altitude float64 // Calculated
+	Azimuth  float64 // Calculated
+}
+
+// Trail is the sampled path of a solar system body
+type Trail struct {
+	Body   string
+	Points []TrailPoint
+}
+
+// MaxTrailPoints bounds the samples in one trail. Every sample computes the
+// whole planetary system, and trails are re-projected on each tick.
+const MaxTrailPoints = 2000
+
+// TrailPoints is the number of samples a trail of span each side at step
+// needs
+func TrailPoints(span, step time.Duration) int64 {
+	return int64(2*span/step) + 1
+}
+
+// CalculateTrails samples the named bodies every step from center-span to
+// center+span. Bodies that aren't in the planetary system are ignored. The
+// step is widened if it would take more than MaxTrailPoints samples.
+func CalculateTrails(bodies []string, center time.Time, span, step time.Duration, observer *Observer) []Trail {
+	if step <= 0 || span < 0 {
+		return nil
+	}
+	if TrailPoints(span, step) > MaxTrailPoints {
+		step = (2*span + MaxTrailPoints - 2) / (MaxTrailPoints - 1)
+	}
+
+	trails := make([]Trail, len(bodies))
+	for i, body := range bodies {
+		trails[i].Body = body
+	}
+
+	for t := center.Add(-span); !t.After(center.Add(span)); t = t.Add(step) {
+		for _, planet := range CalculatePlanets(t, observer).AllPlanets() {
+			for i := range trails {
+				if trails[i].Body == planet.Name {
+					trails[i].Points = append(trails[i].Points, TrailPoint{
+						Time: t,
+						RA:   planet.RA,
+						Dec:  planet.Dec,
+					})
+				}
+			}
+		}
+	}
+
+	// Drop bodies that matched nothing
+	result := trails[:0]
+	for _, trail := range trails {
+		if len(trail.Points) > 0 {
+			result = append(result, trail)
+		}
+	}
+	return result
+}
+
+// UpdatePositions recomputes every point's altitude and azimuth
+func (tr *Trail) UpdatePositions(observer *Observer, t time.Time) {
+	for i := range tr.Points {
+		eq := EquatorialCoords{RA: tr.Points[i].RA, Dec: tr.Points[i].Dec}
+		hz := EquatorialToHorizontal(eq, observer, t)
+		tr.Points[i].Altitude = hz.Altitude
+		tr.Points[i].Azimuth = hz.Azimuth
+	}
+}
@@ -0,0 +1,45 @@
+package astro
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateTrails(t *testing.T) {
+	center := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	trails := CalculateTrails([]string{"Moon", "Pluto"}, center, 72*time.Hour, 24*time.Hour, DefaultObserver())
+
+	if len(trails) != 1 || trails[0].Body != "Moon" {
+		t.Fatalf("got %d trails, want only the Moon", len(trails))
+	}
+
+	points := trails[0].Points
+	if len(points) != 7 || !points[3].Time.Equal(center) {
+		t.Fatalf("got %d points, want 7 centered on %v", len(points), center)
+	}
+
+	// The Moon moves roughly 13° a day eastward against the stars
+	for i := 1; i < len(points); i++ {
+		step := AngularSeparation(points[i-1].Dec, points[i-1].RA*15, points[i].Dec, points[i].RA*15)
+		if step < 10 || step > 16 {
+			t.Errorf("day %d: moved %.1f°, want about 13°", i, step)
+		}
+	}
+}
+
+func TestCalculateTrailsCapsPoints(t *testing.T) {
+	center := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	span := 10 * 365 * 24 * time.Hour
+	trails := CalculateTrails([]string{"Mars"}, center, span, time.Minute, DefaultObserver())
+
+	if len(trails) != 1 {
+		t.Fatalf("got %d trails, want Mars", len(trails))
+	}
+	points := trails[0].Points
+	if len(points) > MaxTrailPoints || len(points) < MaxTrailPoints-1 {
+		t.Errorf("got %d points, want about %d", len(points), MaxTrailPoints)
+	}
+	if first, last := points[0].Time, points[len(points)-1].Time; !first.Equal(center.Add(-span)) || center.Add(span).Sub(last) > 2*span/(MaxTrailPoints-1) {
+		t.Errorf("trail runs %v to %v, want the whole ±10 years", first, last)
+	}
+}
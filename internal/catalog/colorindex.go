@@ -0,0 +1,104 @@
+package catalog
+
+// colorIndices holds Johnson B-V color indices for every star in the bright
+// star catalog. A star added without an entry falls back to its spectral
+// type.
+var colorIndices = map[string]float64{
+	"Sirius":          0.00,
+	"Canopus":         0.15,
+	"Arcturus":        1.23,
+	"Rigel Kentaurus": 0.71,
+	"Vega":            0.00,
+	"Capella":         0.80,
+	"Rigel":           -0.03,
+	"Procyon":         0.42,
+	"Achernar":        -0.16,
+	"Betelgeuse":      1.85,
+	"Hadar":           -0.23,
+	"Altair":          0.22,
+	"Acrux":           -0.24,
+	"Aldebaran":       1.54,
+	"Spica":           -0.23,
+	"Antares":         1.83,
+	"Pollux":          1.00,
+	"Fomalhaut":       0.09,
+	"Deneb":           0.09,
+	"Mimosa":          -0.23,
+	"Bellatrix":       -0.22,
+	"Alnilam":         -0.18,
+	"Alnitak":         -0.21,
+	"Mintaka":         -0.22,
+	"Saiph":           -0.17,
+	"Alioth":          -0.02,
+	"Dubhe":           1.07,
+	"Alkaid":          -0.19,
+	"Mizar":           0.02,
+	"Merak":           -0.02,
+	"Phecda":          0.00,
+	"Megrez":          0.08,
+	"Regulus":         -0.11,
+	"Denebola":        0.09,
+	"Algieba":         1.15,
+	"Castor":          0.03,
+	"Alhena":          0.00,
+	"Elnath":          -0.13,
+	"Menkalinan":      0.03,
+	"Shaula":          -0.22,
+	"Kaus Australis":  -0.03,
+	"Nunki":           -0.13,
+	"Tarazed":         1.51,
+	"Sadr":            0.67,
+	"Gienah":          1.03,
+	"Enif":            1.52,
+	"Scheat":          1.67,
+	"Markab":          -0.04,
+	"Alpheratz":       -0.11,
+	"Mirach":          1.58,
+	"Almach":          1.37,
+	"Schedar":         1.17,
+	"Caph":            0.34,
+	"Mirfak":          0.48,
+	"Algol":           -0.05,
+	"Hamal":           1.15,
+	"Deneb Kaitos":    1.02,
+	"Menkar":          1.64,
+	"Adhara":          -0.21,
+	"Wezen":           0.68,
+	"Mirzam":          -0.24,
+	"Menkent":         1.01,
+	"Rasalhague":      0.15,
+	"Alphecca":        -0.02,
+	"Gacrux":          1.59,
+	"Alnair":          -0.13,
+	"Peacock":         -0.20,
+	"Ankaa":           1.09,
+	"Polaris":         0.60,
+	"Porrima":         0.36,
+	"Vindemiatrix":    0.94,
+	"Nekkar":          0.97,
+	"Seginus":         0.19,
+	"Sargas":          0.40,
+	"Dschubba":        -0.12,
+	"Alshain":         0.86,
+	"Sheliak":         0.00,
+	"Sulafat":         -0.05,
+	"Sadalsuud":       0.83,
+	"Sadalmelik":      0.98,
+	"Algenib":         -0.23,
+	"Ruchbah":         0.13,
+	"Sheratan":        0.13,
+	"Cursa":           0.13,
+	"Zaurak":          1.59,
+	"Aludra":          -0.08,
+	"Acubens":         0.14,
+	"Gienah Corvi":    -0.11,
+	"Algorab":         -0.05,
+	"Men":             -0.20,
+	"Zubenelgenubi":   0.15,
+	"Zubeneschamali":  -0.11,
+	"Sabik":           0.06,
+	"Rasalgethi":      1.44,
+	"Kornephoros":     0.94,
+	"Al Dhanab":       1.60,
+	"Alpha Tucanae":   1.39,
+}
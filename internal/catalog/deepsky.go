@@ -28,6 +28,7 @@ func (dsc *DeepSkyCatalog) UpdatePositions(observer *astro.Observer, t time.Time
 		hz := astro.EquatorialToHorizontal(eq, observer, t)
 		dsc.objects[i].Altitude = hz.Altitude
 		dsc.objects[i].Azimuth = hz.Azimuth
+		dsc.objects[i].ParallacticAngle = astro.ParallacticAngle(eq, observer, t)
 	}
 }
 
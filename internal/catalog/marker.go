@@ -0,0 +1,45 @@
+package catalog
+
+import (
+	"time"
+
+	"github.com/craigderington/skyterm/internal/astro"
+)
+
+// Marker is a temporary target placed at entered coordinates, such as a
+// transient or comet position from an alert
+type Marker struct {
+	Name    string
+	Entered astro.Coordinates // Coordinates as typed
+
+	RA       float64 // J2000 hours; recomputed for Alt/Az markers
+	Dec      float64 // J2000 degrees; recomputed for Alt/Az markers
+	Altitude float64 // Calculated
+	Azimuth  float64 // Calculated
+}
+
+// NewMarker creates a marker for coordinates entered at time t. JNow
+// coordinates are precessed to J2000 so the marker lines up with the
+// catalogs.
+func NewMarker(name string, c astro.Coordinates, t time.Time) *Marker {
+	m := &Marker{Name: name, Entered: c, RA: c.RA, Dec: c.Dec}
+	if c.Frame == astro.FrameJNow {
+		eq := astro.PrecessToJ2000(astro.EquatorialCoords{RA: c.RA, Dec: c.Dec}, t)
+		m.RA, m.Dec = eq.RA, eq.Dec
+	}
+	return m
+}
+
+// UpdatePosition recomputes the marker's position. Markers entered as
+// Alt/Az stay fixed on the sky dome while the stars turn past them.
+func (m *Marker) UpdatePosition(observer *astro.Observer, t time.Time) {
+	if m.Entered.Frame == astro.FrameHorizontal {
+		m.Altitude, m.Azimuth = m.Entered.Altitude, m.Entered.Azimuth
+		eq := astro.HorizontalToEquatorial(astro.HorizontalCoords{Altitude: m.Altitude, Azimuth: m.Azimuth}, observer, t)
+		m.RA, m.Dec = eq.RA, eq.Dec
+		return
+	}
+
+	hz := astro.EquatorialToHorizontal(astro.EquatorialCoords{RA: m.RA, Dec: m.Dec}, observer, t)
+	m.Altitude, m.Azimuth = hz.Altitude, hz.Azimuth
+}
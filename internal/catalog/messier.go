@@ -2,110 +2,159 @@ package catalog
 
 // MessierObject represents a deep sky object from the Messier catalog
 type MessierObject struct {
-	Number      int
-	Name        string
-	CommonName  string
-	Type        string // Galaxy, Nebula, Cluster, etc.
-	RA          float64 // Right Ascension in hours
-	Dec         float64 // Declination in degrees
-	Magnitude   float64
-	Altitude    float64 // Calculated
-	Azimuth     float64 // Calculated
+	Number     int
+	Name       string
+	CommonName string
+	Aliases    []string // NGC numbers and other names, used by search
+	Type       string   // Galaxy, Nebula, Cluster, etc.
+	RA         float64  // Right Ascension in hours
+	Dec        float64  // Declination in degrees
+	Magnitude  float64
+
+	// Apparent size and orientation
+	MajorAxis     float64 // Arcminutes
+	MinorAxis     float64 // Arcminutes, equal to MajorAxis for round objects
+	PositionAngle float64 // Degrees of the major axis, north through east
+
+	Altitude         float64 // Calculated
+	Azimuth          float64 // Calculated
+	ParallacticAngle float64 // Calculated, degrees
 }
 
-// GetMessierCatalog returns notable Messier objects
+// GetMessierCatalog returns notable Messier objects. Coordinates are J2000;
+// sizes and position angles follow the SEDS Messier database.
 func GetMessierCatalog() []MessierObject {
 	return []MessierObject{
 		// Most famous Messier objects
 		{
-			Number:     1,
-			Name:       "M1",
-			CommonName: "Crab Nebula",
-			Type:       "Supernova Remnant",
-			RA:         5.58,
-			Dec:        22.02,
-			Magnitude:  8.4,
+			Number:        1,
+			Name:          "M1",
+			CommonName:    "Crab Nebula",
+			Aliases:       []string{"NGC 1952", "Taurus A"},
+			Type:          "Supernova Remnant",
+			RA:            5.5755,
+			Dec:           22.0145,
+			Magnitude:     8.4,
+			MajorAxis:     6,
+			MinorAxis:     4,
+			PositionAngle: 125,
 		},
 		{
-			Number:     8,
-			Name:       "M8",
-			CommonName: "Lagoon Nebula",
-			Type:       "Nebula",
-			RA:         18.06,
-			Dec:        -24.38,
-			Magnitude:  6.0,
+			Number:        8,
+			Name:          "M8",
+			CommonName:    "Lagoon Nebula",
+			Aliases:       []string{"NGC 6523"},
+			Type:          "Nebula",
+			RA:            18.0603,
+			Dec:           -24.3867,
+			Magnitude:     6.0,
+			MajorAxis:     90,
+			MinorAxis:     40,
+			PositionAngle: 90,
 		},
 		{
-			Number:     13,
-			Name:       "M13",
-			CommonName: "Hercules Cluster",
-			Type:       "Globular Cluster",
-			RA:         16.69,
-			Dec:        36.46,
-			Magnitude:  5.8,
+			Number:        13,
+			Name:          "M13",
+			CommonName:    "Hercules Cluster",
+			Aliases:       []string{"NGC 6205", "Great Globular Cluster"},
+			Type:          "Globular Cluster",
+			RA:            16.6949,
+			Dec:           36.4613,
+			Magnitude:     5.8,
+			MajorAxis:     20,
+			MinorAxis:     20,
+			PositionAngle: 0,
 		},
 		{
-			Number:     31,
-			Name:       "M31",
-			CommonName: "Andromeda Galaxy",
-			Type:       "Galaxy",
-			RA:         0.71,
-			Dec:        41.27,
-			Magnitude:  3.4,
+			Number:        31,
+			Name:          "M31",
+			CommonName:    "Andromeda Galaxy",
+			Aliases:       []string{"NGC 224", "Andromeda Nebula"},
+			Type:          "Galaxy",
+			RA:            0.7123,
+			Dec:           41.2692,
+			Magnitude:     3.4,
+			MajorAxis:     190,
+			MinorAxis:     60,
+			PositionAngle: 35,
 		},
 		{
-			Number:     42,
-			Name:       "M42",
-			CommonName: "Orion Nebula",
-			Type:       "Nebula",
-			RA:         5.59,
-			Dec:        -5.39,
-			Magnitude:  4.0,
+			Number:        42,
+			Name:          "M42",
+			CommonName:    "Orion Nebula",
+			Aliases:       []string{"NGC 1976", "Great Orion Nebula"},
+			Type:          "Nebula",
+			RA:            5.5881,
+			Dec:           -5.3911,
+			Magnitude:     4.0,
+			MajorAxis:     85,
+			MinorAxis:     60,
+			PositionAngle: 0,
 		},
 		{
-			Number:     44,
-			Name:       "M44",
-			CommonName: "Beehive Cluster",
-			Type:       "Open Cluster",
-			RA:         8.67,
-			Dec:        19.98,
-			Magnitude:  3.7,
+			Number:        44,
+			Name:          "M44",
+			CommonName:    "Beehive Cluster",
+			Aliases:       []string{"NGC 2632", "Praesepe"},
+			Type:          "Open Cluster",
+			RA:            8.6700,
+			Dec:           19.6717,
+			Magnitude:     3.7,
+			MajorAxis:     95,
+			MinorAxis:     95,
+			PositionAngle: 0,
 		},
 		{
-			Number:     45,
-			Name:       "M45",
-			CommonName: "Pleiades",
-			Type:       "Open Cluster",
-			RA:         3.79,
-			Dec:        24.12,
-			Magnitude:  1.6,
+			Number:        45,
+			Name:          "M45",
+			CommonName:    "Pleiades",
+			Aliases:       []string{"Seven Sisters", "Subaru"},
+			Type:          "Open Cluster",
+			RA:            3.7833,
+			Dec:           24.1167,
+			Magnitude:     1.6,
+			MajorAxis:     110,
+			MinorAxis:     110,
+			PositionAngle: 0,
 		},
 		{
-			Number:     51,
-			Name:       "M51",
-			CommonName: "Whirlpool Galaxy",
-			Type:       "Galaxy",
-			RA:         13.50,
-			Dec:        47.20,
-			Magnitude:  8.4,
+			Number:        51,
+			Name:          "M51",
+			CommonName:    "Whirlpool Galaxy",
+			Aliases:       []string{"NGC 5194"},
+			Type:          "Galaxy",
+			RA:            13.4980,
+			Dec:           47.1953,
+			Magnitude:     8.4,
+			MajorAxis:     11.2,
+			MinorAxis:     6.9,
+			PositionAngle: 163,
 		},
 		{
-			Number:     57,
-			Name:       "M57",
-			CommonName: "Ring Nebula",
-			Type:       "Planetary Nebula",
-			RA:         18.89,
-			Dec:        33.03,
-			Magnitude:  8.8,
+			Number:        57,
+			Name:          "M57",
+			CommonName:    "Ring Nebula",
+			Aliases:       []string{"NGC 6720"},
+			Type:          "Planetary Nebula",
+			RA:            18.8931,
+			Dec:           33.0292,
+			Magnitude:     8.8,
+			MajorAxis:     1.4,
+			MinorAxis:     1,
+			PositionAngle: 60,
 		},
 		{
-			Number:     81,
-			Name:       "M81",
-			CommonName: "Bode's Galaxy",
-			Type:       "Galaxy",
-			RA:         9.93,
-			Dec:        69.07,
-			Magnitude:  6.9,
+			Number:        81,
+			Name:          "M81",
+			CommonName:    "Bode's Galaxy",
+			Aliases:       []string{"NGC 3031"},
+			Type:          "Galaxy",
+			RA:            9.9259,
+			Dec:           69.0653,
+			Magnitude:     6.9,
+			MajorAxis:     26.9,
+			MinorAxis:     14.1,
+			PositionAngle: 157,
 		},
 	}
 }
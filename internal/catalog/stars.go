@@ -13,8 +13,16 @@ type StarCatalog struct {
 
 // NewStarCatalog creates a new star catalog
 func NewStarCatalog() *StarCatalog {
+	stars := loadBrightStars()
+	for i := range stars {
+		if bv, ok := colorIndices[stars[i].Name]; ok {
+			stars[i].ColorIndex = bv
+			stars[i].HasColorIndex = true
+		}
+	}
+
 	return &StarCatalog{
-		stars: loadBrightStars(),
+		stars: stars,
 	}
 }
 
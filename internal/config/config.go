@@ -1,10 +1,15 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/optics"
 	"gopkg.in/yaml.v3"
 )
 
@@ -14,6 +19,18 @@ type Config struct {
 	Display  DisplayConfig
 	Time     TimeConfig
 	Controls ControlsConfig
+
+	// Telescopes, eyepieces, cameras and finders for field of view overlays
+	Equipment optics.Equipment
+
+	// Key overrides by action name, e.g. south: [ctrl+s]
+	Keys map[string]KeyList
+
+	// Other observing sites to switch between
+	Sites []SiteConfig
+
+	// Problems found loading the file; the values involved use defaults
+	Warnings []string `yaml:"-"`
 }
 
 // LocationConfig holds observer location settings
@@ -22,6 +39,11 @@ type LocationConfig struct {
 	Longitude float64 `yaml:"longitude"`
 	Altitude  float64 `yaml:"altitude"`
 	Name      string  `yaml:"name"`
+	TimeZone  string  `yaml:"timezone"` // IANA name; empty uses the machine's zone
+	Site      string  `yaml:"site"`     // Start at this entry of sites instead
+
+	// Zenith extinction coefficient in magnitudes per airmass at sea level
+	ExtinctionCoefficient float64 `yaml:"extinction_coefficient"`
 }
 
 // DisplayConfig holds display settings
@@ -33,11 +55,15 @@ type DisplayConfig struct {
 	ShowPlanetLabels       bool    `yaml:"show_planet_labels"`
 	ColorStarsByType       bool    `yaml:"color_stars_by_type"`
 	UseBrailleRendering    bool    `yaml:"use_braille_rendering"`
+	Theme                  string  `yaml:"theme"` // default, high-contrast, monochrome, night
+	SimulateDaylight       bool    `yaml:"simulate_daylight"`
+	TrailSpan              string  `yaml:"trail_span"` // Trail length each side of now, e.g. "30d"
+	TrailStep              string  `yaml:"trail_step"` // Time between trail points, e.g. "1d"
 }
 
 // TimeConfig holds time-related settings
 type TimeConfig struct {
-	UseUTC   bool   `yaml:"use_utc"`
+	UseUTC   bool   `yaml:"use_utc"` // Start showing UTC instead of the site's zone
 	TimeStep string `yaml:"time_step"`
 }
 
@@ -46,61 +72,82 @@ type ControlsConfig struct {
 	PanSpeed         float64 `yaml:"pan_speed"`
 	FastPanMultiplier float64 `yaml:"fast_pan_multiplier"`
 	ZoomStep         float64 `yaml:"zoom_step"`
+	MinFOV           float64 `yaml:"min_fov"` // Narrowest field of view in degrees
 }
 
 // Load loads configuration from XDG config directory
 // Falls back to defaults if config file doesn't exist
 func Load() (*Config, error) {
-	configPath := getConfigPath()
-
-	// If config file doesn't exist, return defaults
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	cfg, err := LoadFile(getConfigPath())
+	if os.IsNotExist(err) {
 		return DefaultConfig(), nil
 	}
+	return cfg, err
+}
 
-	// Read config file
-	data, err := os.ReadFile(configPath)
+// LoadFile loads configuration from a file over the defaults, so any field
+// it leaves out keeps its default. A location moved from the default
+// coordinates without a name of its own is called "Home" rather than New
+// York City. Unknown fields and bad values are collected in Warnings. If the
+// file can't be read or parsed at all, the defaults are returned along with
+// the error.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return DefaultConfig(), nil
+		return DefaultConfig(), err
 	}
 
-	// Parse YAML
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return DefaultConfig(), nil
-	}
+	cfg := DefaultConfig()
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
 
-	// Merge with defaults for any missing values
-	defaults := DefaultConfig()
-	if cfg.Location.Latitude == 0 && cfg.Location.Longitude == 0 {
-		cfg.Location = defaults.Location
-	}
-	if cfg.Display.MagnitudeLimit == 0 {
-		cfg.Display = defaults.Display
+	var typeErr *yaml.TypeError
+	err = decoder.Decode(cfg)
+	switch {
+	case errors.As(err, &typeErr):
+		cfg.Warnings = decodeWarnings(typeErr.Errors)
+	case err != nil && err != io.EOF: // An empty file is all defaults
+		return DefaultConfig(), fmt.Errorf("%s: %w", path, err)
 	}
-	if cfg.Controls.PanSpeed == 0 {
-		cfg.Controls = defaults.Controls
+
+	// A location given by coordinates alone isn't New York, so it doesn't
+	// keep the default name
+	home := DefaultConfig().Location
+	moved := cfg.Location.Latitude != home.Latitude || cfg.Location.Longitude != home.Longitude
+	if moved && cfg.Location.Name == home.Name {
+		cfg.Location.Name = "Home"
 	}
 
-	return &cfg, nil
+	cfg.Warnings = append(cfg.Warnings, cfg.Validate()...)
+	return cfg, nil
 }
 
-// Observer creates an Observer from the location configuration
+// Observer creates an Observer at the start site
 func (c *Config) Observer() *astro.Observer {
-	return astro.NewObserver(
-		c.Location.Latitude,
-		c.Location.Longitude,
-		c.Location.Altitude,
-		c.Location.Name,
-	)
+	return c.StartSite().Observer(c.Location.ExtinctionCoefficient)
+}
+
+// StartupScriptPath returns the path of the command script run at startup
+func StartupScriptPath() string {
+	return filepath.Join(getConfigDir(), "startup")
+}
+
+// Path returns the config file read by Load
+func Path() string {
+	return getConfigPath()
 }
 
 // getConfigPath returns the XDG config path for skyterm
 func getConfigPath() string {
+	return filepath.Join(getConfigDir(), "config.yaml")
+}
+
+// getConfigDir returns the XDG config directory for skyterm
+func getConfigDir() string {
 	xdgConfig := os.Getenv("XDG_CONFIG_HOME")
 	if xdgConfig == "" {
 		home, _ := os.UserHomeDir()
 		xdgConfig = filepath.Join(home, ".config")
 	}
-	return filepath.Join(xdgConfig, "skyterm", "config.yaml")
+	return filepath.Join(xdgConfig, "skyterm")
 }
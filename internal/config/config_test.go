@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// loadYAML loads text as a config file
+func loadYAML(t *testing.T, text string) *Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	return cfg
+}
+
+// wantWarning fails unless exactly one warning contains want
+func wantWarning(t *testing.T, cfg *Config, want string) {
+	t.Helper()
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], want) {
+		t.Errorf("warnings = %q, want one containing %q", cfg.Warnings, want)
+	}
+}
+
+func TestLoadFileMergesOverDefaults(t *testing.T) {
+	d := DefaultConfig()
+
+	t.Run("location name only", func(t *testing.T) {
+		cfg := loadYAML(t, "location:\n  name: Rooftop\n")
+		if cfg.Location.Name != "Rooftop" {
+			t.Errorf("name = %q, want Rooftop", cfg.Location.Name)
+		}
+		if cfg.Location.Latitude != d.Location.Latitude || cfg.Location.Longitude != d.Location.Longitude {
+			t.Errorf("coordinates = %v, %v, want the defaults", cfg.Location.Latitude, cfg.Location.Longitude)
+		}
+		if len(cfg.Warnings) > 0 {
+			t.Errorf("warnings = %q", cfg.Warnings)
+		}
+	})
+
+	t.Run("display without magnitude_limit", func(t *testing.T) {
+		cfg := loadYAML(t, "display:\n  theme: night\n  show_coordinate_grid: true\n")
+		want := d.Display
+		want.Theme = "night"
+		want.ShowCoordinateGrid = true
+		if cfg.Display != want {
+			t.Errorf("display = %+v, want %+v", cfg.Display, want)
+		}
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		cfg := loadYAML(t, "")
+		if cfg.Display != d.Display || cfg.Location != d.Location || len(cfg.Warnings) > 0 {
+			t.Errorf("empty file = %+v, want the defaults", cfg)
+		}
+	})
+}
+
+func TestLoadFileNamesMovedLocationHome(t *testing.T) {
+	cfg := loadYAML(t, "location:\n  latitude: 51.5\n  longitude: -0.13\n")
+	if cfg.Location.Name != "Home" {
+		t.Errorf("name = %q, want Home", cfg.Location.Name)
+	}
+
+	cfg = loadYAML(t, "location:\n  latitude: 51.5\n  longitude: -0.13\n  name: London\n")
+	if cfg.Location.Name != "London" {
+		t.Errorf("name = %q, want London", cfg.Location.Name)
+	}
+}
+
+func TestLoadFileWarnings(t *testing.T) {
+	d := DefaultConfig()
+	tests := []struct {
+		name  string
+		yaml  string
+		warn  string
+		check func(*Config) bool // The bad value was replaced by its default
+	}{
+		{
+			name:  "bad time_step",
+			yaml:  "time:\n  time_step: soon\n",
+			warn:  `time.time_step: soon is not a positive duration like "1m" or "1h30m"; using 1m`,
+			check: func(c *Config) bool { return c.Time.TimeStep == d.Time.TimeStep },
+		},
+		{
+			name:  "negative time_step",
+			yaml:  "time:\n  time_step: -5m\n",
+			warn:  "time.time_step: -5m",
+			check: func(c *Config) bool { return c.Time.TimeStep == d.Time.TimeStep },
+		},
+		{
+			name:  "zoom_step below 1",
+			yaml:  "controls:\n  zoom_step: 0.5\n",
+			warn:  "controls.zoom_step: 0.5 must be greater than 1; using 1.2",
+			check: func(c *Config) bool { return c.Controls.ZoomStep == d.Controls.ZoomStep },
+		},
+		{
+			name:  "zoom_step of 1",
+			yaml:  "controls:\n  zoom_step: 1\n",
+			warn:  "controls.zoom_step: 1 must be greater than 1",
+			check: func(c *Config) bool { return c.Controls.ZoomStep == d.Controls.ZoomStep },
+		},
+		{
+			name:  "unknown field",
+			yaml:  "display:\n  theme: night\n  colour: red\n",
+			warn:  `line 3: unknown field "colour"`,
+			check: func(c *Config) bool { return c.Display.Theme == "night" },
+		},
+		{
+			name: "text for a number",
+			yaml: "display:\n  magnitude_limit: bright\n  theme: night\n",
+			warn: `line 2: "bright" should be a number`,
+			check: func(c *Config) bool {
+				return c.Display.MagnitudeLimit == d.Display.MagnitudeLimit && c.Display.Theme == "night"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadYAML(t, tt.yaml)
+			wantWarning(t, cfg, tt.warn)
+			if !tt.check(cfg) {
+				t.Errorf("config = %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml")); !os.IsNotExist(err) {
+		t.Errorf("missing file: err = %v, want not exist", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("display: [\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFile(path)
+	if err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("unparsable file: err = %v, want one naming the file", err)
+	}
+	if cfg == nil || len(cfg.Warnings) > 0 || cfg.Display != DefaultConfig().Display {
+		t.Errorf("unparsable file: config = %+v, want the defaults", cfg)
+	}
+}
@@ -8,6 +8,8 @@ func DefaultConfig() *Config {
 			Longitude: -74.0060,
 			Altitude:  10.0,
 			Name:      "New York City",
+
+			ExtinctionCoefficient: 0.2,
 		},
 		Display: DisplayConfig{
 			MagnitudeLimit:         5.0,
@@ -17,6 +19,10 @@ func DefaultConfig() *Config {
 			ShowPlanetLabels:       false,
 			ColorStarsByType:       true,
 			UseBrailleRendering:    false,
+			Theme:                  "default",
+			SimulateDaylight:       true,
+			TrailSpan:              "30d",
+			TrailStep:              "1d",
 		},
 		Time: TimeConfig{
 			UseUTC:   false,
@@ -26,6 +32,7 @@ func DefaultConfig() *Config {
 			PanSpeed:          5.0,
 			FastPanMultiplier: 4.0,
 			ZoomStep:          1.2,
+			MinFOV:            0.1,
 		},
 	}
 }
@@ -0,0 +1,21 @@
+package config
+
+import "gopkg.in/yaml.v3"
+
+// KeyList is the keys bound to one action. In YAML it may be a single key
+// ("south: S") or a list ("zoom_in: [+, =]").
+type KeyList []string
+
+// UnmarshalYAML accepts either a scalar or a sequence of keys
+func (k *KeyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = KeyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/craigderington/skyterm/internal/astro"
+)
+
+// SiteConfig is a named observing site
+type SiteConfig struct {
+	Name           string       `yaml:"name"`
+	Latitude       float64      `yaml:"latitude"`
+	Longitude      float64      `yaml:"longitude"`
+	Elevation      float64      `yaml:"elevation"`       // Meters above sea level
+	TimeZone       string       `yaml:"timezone"`        // IANA name, e.g. "America/Denver"
+	Horizon        [][2]float64 `yaml:"horizon"`         // [azimuth, altitude] pairs in degrees
+	MagnitudeLimit float64      `yaml:"magnitude_limit"` // Zero keeps the current limit
+}
+
+// AllSites returns the location section as the first site, followed by the
+// sites list
+func (c *Config) AllSites() []SiteConfig {
+	home := SiteConfig{
+		Name:      c.Location.Name,
+		Latitude:  c.Location.Latitude,
+		Longitude: c.Location.Longitude,
+		Elevation: c.Location.Altitude,
+		TimeZone:  c.Location.TimeZone,
+	}
+	return append([]SiteConfig{home}, c.Sites...)
+}
+
+// FindSite returns the site with the given name, ignoring case
+func (c *Config) FindSite(name string) (SiteConfig, bool) {
+	for _, site := range c.AllSites() {
+		if strings.EqualFold(site.Name, name) {
+			return site, true
+		}
+	}
+	return SiteConfig{}, false
+}
+
+// StartSite returns the site named by location.site, or the location
+// section itself when none is named or the name isn't found
+func (c *Config) StartSite() SiteConfig {
+	if c.Location.Site != "" {
+		if site, ok := c.FindSite(c.Location.Site); ok {
+			return site
+		}
+	}
+	return c.AllSites()[0]
+}
+
+// Observer creates an Observer at the site with the configured extinction.
+// An unknown time zone name leaves the zone unset, so the machine's is used.
+func (s SiteConfig) Observer(extinction float64) *astro.Observer {
+	observer := astro.NewObserver(s.Latitude, s.Longitude, s.Elevation, s.Name)
+	observer.ExtinctionCoefficient = extinction
+	if s.TimeZone != "" {
+		if zone, err := time.LoadLocation(s.TimeZone); err == nil {
+			observer.Zone = zone
+		}
+	}
+
+	if len(s.Horizon) > 0 {
+		points := make([]astro.HorizonPoint, len(s.Horizon))
+		for i, p := range s.Horizon {
+			points[i] = astro.HorizonPoint{Azimuth: p[0], Altitude: p[1]}
+		}
+		observer.Horizon = astro.NewHorizonProfile(points)
+	}
+	return observer
+}
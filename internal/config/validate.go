@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// Validate checks the values YAML decoding can't, such as latitude ranges
+// and durations. Each unusable value is replaced by its default and
+// described in the result; sites and time zones that can't be found are
+// reported but left alone, since they fall back on their own.
+func (c *Config) Validate() []string {
+	d := DefaultConfig()
+	var warnings []string
+	reset := func(field string, value, def any, problem string) {
+		warnings = append(warnings, fmt.Sprintf("%s: %v %s; using %v", field, value, problem, def))
+	}
+
+	if !validLatitude(c.Location.Latitude) {
+		reset("location.latitude", c.Location.Latitude, d.Location.Latitude, "is outside ±90°")
+		c.Location.Latitude = d.Location.Latitude
+	}
+	if !validLongitude(c.Location.Longitude) {
+		reset("location.longitude", c.Location.Longitude, d.Location.Longitude, "is outside ±180°")
+		c.Location.Longitude = d.Location.Longitude
+	}
+	if c.Location.ExtinctionCoefficient < 0 {
+		reset("location.extinction_coefficient", c.Location.ExtinctionCoefficient, d.Location.ExtinctionCoefficient, "is negative")
+		c.Location.ExtinctionCoefficient = d.Location.ExtinctionCoefficient
+	}
+	if err := checkTimeZone(c.Location.TimeZone); err != nil {
+		warnings = append(warnings, "location.timezone: "+err.Error())
+	}
+	if c.Location.Site != "" {
+		if _, ok := c.FindSite(c.Location.Site); !ok {
+			warnings = append(warnings, fmt.Sprintf("location.site: no site named %q; starting at location", c.Location.Site))
+		}
+	}
+
+	if _, err := theme.ByName(c.Display.Theme); err != nil {
+		reset("display.theme", c.Display.Theme, d.Display.Theme, "is not a theme")
+		c.Display.Theme = d.Display.Theme
+	}
+	if step, err := ParseDuration(c.Display.TrailSpan); err != nil || step < 0 {
+		reset("display.trail_span", c.Display.TrailSpan, d.Display.TrailSpan, "is not a duration")
+		c.Display.TrailSpan = d.Display.TrailSpan
+	}
+	if step, err := ParseDuration(c.Display.TrailStep); err != nil || step <= 0 {
+		reset("display.trail_step", c.Display.TrailStep, d.Display.TrailStep, "is not a positive duration")
+		c.Display.TrailStep = d.Display.TrailStep
+	}
+	span, _ := ParseDuration(c.Display.TrailSpan)
+	step, _ := ParseDuration(c.Display.TrailStep)
+	if n := astro.TrailPoints(span, step); n > astro.MaxTrailPoints {
+		warnings = append(warnings, fmt.Sprintf("display.trail_span: %s at a trail_step of %s takes %d points per trail, over the limit of %d; using %s and %s",
+			c.Display.TrailSpan, c.Display.TrailStep, n, astro.MaxTrailPoints, d.Display.TrailSpan, d.Display.TrailStep))
+		c.Display.TrailSpan = d.Display.TrailSpan
+		c.Display.TrailStep = d.Display.TrailStep
+	}
+
+	if step, err := time.ParseDuration(c.Time.TimeStep); err != nil || step <= 0 {
+		reset("time.time_step", c.Time.TimeStep, d.Time.TimeStep, `is not a positive duration like "1m" or "1h30m"`)
+		c.Time.TimeStep = d.Time.TimeStep
+	}
+
+	if c.Controls.PanSpeed <= 0 {
+		reset("controls.pan_speed", c.Controls.PanSpeed, d.Controls.PanSpeed, "is not positive")
+		c.Controls.PanSpeed = d.Controls.PanSpeed
+	}
+	if c.Controls.FastPanMultiplier <= 0 {
+		reset("controls.fast_pan_multiplier", c.Controls.FastPanMultiplier, d.Controls.FastPanMultiplier, "is not positive")
+		c.Controls.FastPanMultiplier = d.Controls.FastPanMultiplier
+	}
+	if c.Controls.ZoomStep <= 1 {
+		reset("controls.zoom_step", c.Controls.ZoomStep, d.Controls.ZoomStep, "must be greater than 1")
+		c.Controls.ZoomStep = d.Controls.ZoomStep
+	}
+	if c.Controls.MinFOV <= 0 || c.Controls.MinFOV >= 120 {
+		reset("controls.min_fov", c.Controls.MinFOV, d.Controls.MinFOV, "is outside 0° to 120°")
+		c.Controls.MinFOV = d.Controls.MinFOV
+	}
+
+	for i, site := range c.Sites {
+		field := fmt.Sprintf("sites[%d]", i)
+		if site.Name == "" {
+			warnings = append(warnings, field+": has no name")
+		} else {
+			field = fmt.Sprintf("sites[%q]", site.Name)
+		}
+		if !validLatitude(site.Latitude) || !validLongitude(site.Longitude) {
+			warnings = append(warnings, field+": latitude must be within ±90° and longitude within ±180°")
+		}
+		if err := checkTimeZone(site.TimeZone); err != nil {
+			warnings = append(warnings, field+".timezone: "+err.Error())
+		}
+	}
+
+	return warnings
+}
+
+func validLatitude(lat float64) bool  { return lat >= -90 && lat <= 90 }
+func validLongitude(lon float64) bool { return lon >= -180 && lon <= 180 }
+
+// checkTimeZone reports a zone name that can't be loaded; the machine's
+// zone is used instead
+func checkTimeZone(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("unknown time zone %q; using the machine's zone", name)
+	}
+	return nil
+}
+
+var (
+	// unknownField matches the YAML decoder's message for a misspelled key
+	unknownField = regexp.MustCompile(`field (\S+) not found in type \S+`)
+
+	// wrongType matches its message for a value of the wrong type
+	wrongType = regexp.MustCompile("cannot unmarshal !!\\w+ `([^`]*)` into (\\S+)")
+)
+
+// typeNames describes Go types in wrong type warnings
+var typeNames = map[string]string{
+	"float64": "a number",
+	"int":     "a whole number",
+	"bool":    "true or false",
+	"string":  "text",
+}
+
+// decodeWarnings rewrites the YAML decoder's per-field errors, e.g. a
+// misspelled key or text where a number belongs, as warnings. The fields
+// they name keep their defaults.
+func decodeWarnings(errs []string) []string {
+	warnings := make([]string, len(errs))
+	for i, e := range errs {
+		e = unknownField.ReplaceAllString(e, `unknown field "$1"`)
+		if m := wrongType.FindStringSubmatch(e); m != nil {
+			want, ok := typeNames[m[2]]
+			if !ok {
+				want = "a " + strings.TrimPrefix(m[2], "config.")
+			}
+			e = strings.Replace(e, m[0], fmt.Sprintf("%q should be %s", m[1], want), 1)
+		}
+		warnings[i] = e
+	}
+	return warnings
+}
@@ -0,0 +1,200 @@
+// Package gazetteer resolves place names to coordinates offline, from an
+// embedded table of cities
+package gazetteer
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:generate go run gencities.go
+
+// cities.tsv is a hand-picked subset of the GeoNames cities15000 dump.
+// Running gencities.go replaces it with every city in the dump.
+//
+//go:embed cities.tsv
+var citiesTSV string
+
+// earthRadius is the mean radius of the Earth in kilometers
+const earthRadius = 6371.0
+
+// City is one entry of the gazetteer
+type City struct {
+	Name       string
+	Region     string // State, province or home nation code; empty for most countries
+	Country    string // ISO 3166 two-letter code
+	Latitude   float64
+	Longitude  float64
+	Elevation  float64 // Meters above sea level
+	TimeZone   string  // IANA name
+	Population int
+
+	folded string // Name as matched by fold
+}
+
+// Place is the region and country, e.g. "AZ, US"
+func (c City) Place() string {
+	if c.Region == "" {
+		return c.Country
+	}
+	return c.Region + ", " + c.Country
+}
+
+// String is the name with its place, e.g. "Flagstaff, AZ, US"
+func (c City) String() string {
+	return c.Name + ", " + c.Place()
+}
+
+// Cities returns every city in the gazetteer, most populous first
+var Cities = sync.OnceValue(func() []City {
+	cities, err := parse(citiesTSV)
+	if err != nil {
+		panic("gazetteer: " + err.Error())
+	}
+	slices.SortStableFunc(cities, func(a, b City) int {
+		return b.Population - a.Population
+	})
+	return cities
+})
+
+// parse reads tab-separated cities, skipping blank lines and "#" comments
+func parse(data string) ([]City, error) {
+	var cities []City
+	for i, line := range strings.Split(data, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f := strings.Split(line, "\t")
+		if len(f) != 8 {
+			return nil, fmt.Errorf("line %d: expected 8 fields, got %d", i+1, len(f))
+		}
+
+		c := City{Name: f[0], Region: f[1], Country: f[2], TimeZone: f[6], folded: fold(f[0])}
+		var errs [4]error
+		c.Latitude, errs[0] = strconv.ParseFloat(f[3], 64)
+		c.Longitude, errs[1] = strconv.ParseFloat(f[4], 64)
+		c.Elevation, errs[2] = strconv.ParseFloat(f[5], 64)
+		c.Population, errs[3] = strconv.Atoi(f[7])
+		for _, err := range errs {
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+		}
+		cities = append(cities, c)
+	}
+	return cities, nil
+}
+
+// Find returns the cities named exactly by query, most populous first.
+// Case and accents are ignored. The name may be followed by a region and
+// country after commas to narrow it, e.g. "Portland, ME" or "Paris, FR".
+func Find(query string) []City {
+	name, qualifiers := splitQuery(query)
+	var found []City
+	for _, c := range Cities() {
+		if c.folded == name && qualified(c, qualifiers) {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+// Search returns up to limit cities whose names start with query, exact
+// names first and otherwise most populous first
+func Search(query string, limit int) []City {
+	name, qualifiers := splitQuery(query)
+	if name == "" {
+		return nil
+	}
+
+	var exact, prefix []City
+	for _, c := range Cities() {
+		if !strings.HasPrefix(c.folded, name) || !qualified(c, qualifiers) {
+			continue
+		}
+		if c.folded == name {
+			exact = append(exact, c)
+		} else {
+			prefix = append(prefix, c)
+		}
+	}
+
+	found := append(exact, prefix...)
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found
+}
+
+// Nearest returns the city closest to a point and its distance in km
+func Nearest(lat, lon float64) (City, float64) {
+	var nearest City
+	best := math.Inf(1)
+	for _, c := range Cities() {
+		if d := Distance(lat, lon, c.Latitude, c.Longitude); d < best {
+			nearest, best = c, d
+		}
+	}
+	return nearest, best
+}
+
+// Distance is the great-circle distance in km between two points
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	const rad = math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// splitQuery separates the folded name from any region or country after
+// commas
+func splitQuery(query string) (name string, qualifiers []string) {
+	parts := strings.Split(query, ",")
+	for _, q := range parts[1:] {
+		if q = fold(q); q != "" {
+			qualifiers = append(qualifiers, q)
+		}
+	}
+	return fold(parts[0]), qualifiers
+}
+
+// qualified reports whether every qualifier names the city's region or
+// country
+func qualified(c City, qualifiers []string) bool {
+	for _, q := range qualifiers {
+		if q != fold(c.Region) && q != fold(c.Country) {
+			return false
+		}
+	}
+	return true
+}
+
+// accents maps accented letters used in the gazetteer to plain ones
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ā", "a", "ă", "a", "ą", "a", "ē", "e", "ė", "e", "ę", "e", "ě", "e",
+	"ī", "i", "į", "i", "ı", "i", "ō", "o", "ő", "o", "ū", "u", "ů", "u", "ű", "u", "ų", "u",
+	"ý", "y", "ÿ", "y", "ñ", "n", "ń", "n", "ň", "n", "ņ", "n",
+	"ç", "c", "ć", "c", "č", "c", "ď", "d", "đ", "d", "ğ", "g", "ģ", "g", "ķ", "k",
+	"ł", "l", "ľ", "l", "ļ", "l", "ř", "r", "ś", "s", "š", "s", "ș", "s", "ş", "s",
+	"ť", "t", "ț", "t", "ţ", "t", "ź", "z", "ż", "z", "ž", "z",
+	"ß", "ss", "æ", "ae", "œ", "oe", "þ", "th", "ð", "d",
+	".", "", "'", "", "’", "",
+)
+
+// fold lowercases, trims and strips accents and punctuation so "sao paulo"
+// finds "São Paulo" and "St Louis" finds "St. Louis"
+func fold(s string) string {
+	return strings.Join(strings.Fields(accents.Replace(strings.ToLower(s))), " ")
+}
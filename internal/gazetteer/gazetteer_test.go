@@ -0,0 +1,113 @@
+package gazetteer
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestCitiesAreValid(t *testing.T) {
+	cities := Cities()
+	if len(cities) < 300 {
+		t.Fatalf("only %d cities", len(cities))
+	}
+	for _, c := range cities {
+		if c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180 {
+			t.Errorf("%s: coordinates %g, %g out of range", c, c.Latitude, c.Longitude)
+		}
+		if c.Population < 15000 {
+			t.Errorf("%s: population %d below 15000", c, c.Population)
+		}
+		if len(c.Country) != 2 {
+			t.Errorf("%s: country %q is not a two-letter code", c, c.Country)
+		}
+		if _, err := time.LoadLocation(c.TimeZone); err != nil {
+			t.Errorf("%s: %v", c, err)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"Flagstaff", []string{"Flagstaff, AZ, US"}},
+		{"  flagstaff ", []string{"Flagstaff, AZ, US"}},
+		{"Portland", []string{"Portland, OR, US", "Portland, ME, US"}},
+		{"Portland, ME", []string{"Portland, ME, US"}},
+		{"paris, fr", []string{"Paris, FR"}},
+		{"London, ON, CA", []string{"London, ON, CA"}},
+		{"sao paulo", []string{"São Paulo, BR"}},
+		{"St Louis", []string{"St. Louis, MO, US"}},
+		{"Portland, FR", nil},
+		{"Atlantis", nil},
+	}
+	for _, tt := range tests {
+		got := Find(tt.query)
+		if len(got) != len(tt.want) {
+			t.Errorf("Find(%q) = %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i].String() != tt.want[i] {
+				t.Errorf("Find(%q)[%d] = %s, want %s", tt.query, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestSearchPutsExactNamesFirst(t *testing.T) {
+	got := Search("paris", 5)
+	if len(got) == 0 || got[0].String() != "Paris, FR" {
+		t.Fatalf("Search(paris) = %v", got)
+	}
+
+	got = Search("san", 3)
+	if len(got) != 3 {
+		t.Fatalf("Search(san) returned %d cities, want 3", len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Population > got[i-1].Population {
+			t.Errorf("Search(san) not ordered by population: %v", got)
+		}
+	}
+
+	if got := Search("", 5); got != nil {
+		t.Errorf("Search(\"\") = %v, want nil", got)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	// Lowell Observatory, on Mars Hill just west of downtown Flagstaff
+	c, d := Nearest(35.2029, -111.6646)
+	if c.Name != "Flagstaff" || d > 5 {
+		t.Errorf("Nearest = %s at %.1f km, want Flagstaff within 5 km", c, d)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	// London to Paris is about 344 km
+	if d := Distance(51.5085, -0.1257, 48.8534, 2.3488); d < 340 || d > 348 {
+		t.Errorf("Distance = %.1f km, want about 344", d)
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := map[string]string{
+		"São Paulo":        "sao paulo",
+		"Łódź":             "lodz",
+		"Plzeň":            "plzen",
+		"České Budějovice": "ceske budejovice",
+		"Kraków":           "krakow",
+		"İzmir":            "izmir",
+		"St. John's":       "st johns",
+		"Düsseldorf":       "dusseldorf",
+		"  New   York ":    "new york",
+	}
+	for in, want := range tests {
+		if got := fold(in); got != want {
+			t.Errorf("fold(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+//go:build ignore
+
+// gencities writes cities.tsv from the GeoNames cities15000 dump.
+//
+//	go generate ./internal/gazetteer
+//
+// downloads the dump; pass -in to read a cities15000.zip or .txt already on
+// disk instead.
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"cmp"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const dumpURL = "https://download.geonames.org/export/dump/cities15000.zip"
+
+// minPopulation drops the capitals the dump keeps below 15,000 people
+const minPopulation = 15000
+
+// regionCodes maps GeoNames admin1 codes to the abbreviations people type,
+// for the countries whose regions the gazetteer shows. US and GB admin1
+// codes are already abbreviations.
+var regionCodes = map[string]map[string]string{
+	"CA": {
+		"01": "AB", "02": "BC", "03": "MB", "04": "NB", "05": "NL", "07": "NS",
+		"08": "ON", "09": "PE", "10": "QC", "11": "SK", "12": "YT", "13": "NT", "14": "NU",
+	},
+	"AU": {
+		"01": "ACT", "02": "NSW", "03": "NT", "04": "QLD", "05": "SA", "06": "TAS", "07": "VIC", "08": "WA",
+	},
+	"ZA": {
+		"02": "KZN", "03": "FS", "05": "EC", "06": "GP", "07": "MP", "08": "NC", "09": "LP", "10": "NW", "11": "WC",
+	},
+}
+
+// GeoNames columns used, see https://download.geonames.org/export/dump/readme.txt
+const (
+	colName       = 1
+	colLatitude   = 4
+	colLongitude  = 5
+	colCountry    = 8
+	colAdmin1     = 10
+	colPopulation = 14
+	colElevation  = 15
+	colDEM        = 16
+	colTimeZone   = 17
+	numCols       = 19
+)
+
+const header = `# Cities with more than 15,000 people from the GeoNames cities15000
+# gazetteer (CC BY 4.0, https://www.geonames.org). Generated by
+# gencities.go; do not edit. Elevations are approximate.
+# name	region	country	latitude	longitude	elevation_m	timezone	population
+`
+
+type city struct {
+	name, region, country string
+	lat, lon              float64
+	elevation             int
+	zone                  string
+	population            int
+}
+
+func main() {
+	in := flag.String("in", "", "cities15000.zip or .txt to read instead of downloading")
+	out := flag.String("out", "cities.tsv", "file to write")
+	flag.Parse()
+
+	data, err := load(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cities, err := parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	slices.SortFunc(cities, func(a, b city) int {
+		return cmp.Or(b.population-a.population, cmp.Compare(a.name, b.name), cmp.Compare(a.country, b.country))
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	for _, c := range cities {
+		fmt.Fprintf(&buf, "%s\t%s\t%s\t%.4f\t%.4f\t%d\t%s\t%d\n",
+			c.name, c.region, c.country, c.lat, c.lon, c.elevation, c.zone, c.population)
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d cities to %s", len(cities), *out)
+}
+
+// load returns the dump's text, from path or downloaded when path is empty
+func load(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "" {
+		data, err = download(dumpURL)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, []byte("PK")) {
+		return data, nil
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if strings.HasSuffix(f.Name, ".txt") {
+			r, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+			return io.ReadAll(r)
+		}
+	}
+	return nil, fmt.Errorf("no .txt file in the archive")
+}
+
+func download(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parse reads the tab-separated dump
+func parse(data []byte) ([]city, error) {
+	var cities []city
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		f := strings.Split(scanner.Text(), "\t")
+		if len(f) != numCols {
+			return nil, fmt.Errorf("line %d: expected %d fields, got %d", line, numCols, len(f))
+		}
+
+		c := city{name: f[colName], country: f[colCountry], zone: f[colTimeZone]}
+		var errs [3]error
+		c.lat, errs[0] = strconv.ParseFloat(f[colLatitude], 64)
+		c.lon, errs[1] = strconv.ParseFloat(f[colLongitude], 64)
+		c.population, errs[2] = strconv.Atoi(f[colPopulation])
+		for _, err := range errs {
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		if c.population < minPopulation || c.zone == "" {
+			continue
+		}
+
+		c.region = region(c.country, f[colAdmin1])
+		c.elevation = elevation(f[colElevation], f[colDEM])
+		cities = append(cities, c)
+	}
+	return cities, scanner.Err()
+}
+
+// region is the abbreviation shown for a city's admin1 code, empty outside
+// the countries listed in regionCodes
+func region(country, admin1 string) string {
+	switch country {
+	case "US", "GB":
+		return admin1
+	}
+	return regionCodes[country][admin1]
+}
+
+// elevation prefers the surveyed elevation, then the terrain model, which
+// marks missing data as -9999
+func elevation(surveyed, dem string) int {
+	if e, err := strconv.Atoi(surveyed); err == nil {
+		return e
+	}
+	if e, err := strconv.Atoi(dem); err == nil && e > -1000 {
+		return e
+	}
+	return 0
+}
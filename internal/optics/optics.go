@@ -0,0 +1,150 @@
+package optics
+
+import (
+	"fmt"
+	"math"
+)
+
+// Telescope describes an objective: refractor, reflector or camera lens
+type Telescope struct {
+	Name        string  `yaml:"name"`
+	FocalLength float64 `yaml:"focal_length"` // Millimeters
+	Aperture    float64 `yaml:"aperture"`     // Millimeters
+}
+
+// Eyepiece describes an eyepiece used with a telescope
+type Eyepiece struct {
+	Name        string  `yaml:"name"`
+	FocalLength float64 `yaml:"focal_length"` // Millimeters
+	ApparentFOV float64 `yaml:"apparent_fov"` // Degrees
+}
+
+// Camera describes an imaging sensor used at a telescope's focus
+type Camera struct {
+	Name      string  `yaml:"name"`
+	PixelSize float64 `yaml:"pixel_size"` // Micrometers
+	Width     int     `yaml:"width"`      // Pixels
+	Height    int     `yaml:"height"`     // Pixels
+	Rotation  float64 `yaml:"rotation"`   // Degrees, frame angle relative to vertical
+}
+
+// Finder describes a finder scope or binoculars with a fixed field
+type Finder struct {
+	Name          string  `yaml:"name"`
+	Magnification float64 `yaml:"magnification"`
+	Aperture      float64 `yaml:"aperture"` // Millimeters
+	FOV           float64 `yaml:"fov"`      // True field in degrees
+}
+
+// Equipment lists everything available for framing targets
+type Equipment struct {
+	Telescopes []Telescope `yaml:"telescopes"`
+	Eyepieces  []Eyepiece  `yaml:"eyepieces"`
+	Cameras    []Camera    `yaml:"cameras"`
+	Finders    []Finder    `yaml:"finders"`
+}
+
+// Setup is one equipment combination and the field of view it produces
+type Setup struct {
+	Name          string
+	Circular      bool    // Eyepieces and finders have round fields, sensors don't
+	Width         float64 // True field in degrees
+	Height        float64 // True field in degrees, equal to Width when circular
+	Rotation      float64 // Degrees, rectangular frames only
+	Magnification float64 // Zero for cameras
+	ExitPupil     float64 // Millimeters, zero when unknown
+}
+
+// EyepieceSetup combines a telescope with an eyepiece
+func EyepieceSetup(t Telescope, e Eyepiece) Setup {
+	mag := t.FocalLength / e.FocalLength
+	fov := e.ApparentFOV / mag
+
+	return Setup{
+		Name:          fmt.Sprintf("%s + %s", t.Name, e.Name),
+		Circular:      true,
+		Width:         fov,
+		Height:        fov,
+		Magnification: mag,
+		ExitPupil:     t.Aperture / mag,
+	}
+}
+
+// CameraSetup combines a telescope with a camera sensor
+func CameraSetup(t Telescope, c Camera) Setup {
+	return Setup{
+		Name:     fmt.Sprintf("%s + %s", t.Name, c.Name),
+		Width:    sensorField(float64(c.Width)*c.PixelSize/1000.0, t.FocalLength),
+		Height:   sensorField(float64(c.Height)*c.PixelSize/1000.0, t.FocalLength),
+		Rotation: c.Rotation,
+	}
+}
+
+// FinderSetup describes a finder's field
+func FinderSetup(f Finder) Setup {
+	setup := Setup{
+		Name:          f.Name,
+		Circular:      true,
+		Width:         f.FOV,
+		Height:        f.FOV,
+		Magnification: f.Magnification,
+	}
+	if f.Magnification > 0 {
+		setup.ExitPupil = f.Aperture / f.Magnification
+	}
+	return setup
+}
+
+// Setups returns every usable combination: each telescope with each
+// eyepiece and each camera, followed by the finders
+func (e Equipment) Setups() []Setup {
+	var setups []Setup
+
+	for _, t := range e.Telescopes {
+		if t.FocalLength <= 0 {
+			continue
+		}
+		for _, ep := range e.Eyepieces {
+			if ep.FocalLength > 0 && ep.ApparentFOV > 0 {
+				setups = append(setups, EyepieceSetup(t, ep))
+			}
+		}
+		for _, c := range e.Cameras {
+			if c.PixelSize > 0 && c.Width > 0 && c.Height > 0 {
+				setups = append(setups, CameraSetup(t, c))
+			}
+		}
+	}
+
+	for _, f := range e.Finders {
+		if f.FOV > 0 {
+			setups = append(setups, FinderSetup(f))
+		}
+	}
+
+	return setups
+}
+
+// Summary returns a short description for the status bar
+func (s Setup) Summary() string {
+	if s.Magnification > 0 {
+		return fmt.Sprintf("%s: %.0f× %.1fmm %s", s.Name, s.Magnification, s.ExitPupil, FormatField(s.Width))
+	}
+	if s.Rotation != 0 {
+		return fmt.Sprintf("%s: %s×%s @%.0f°", s.Name, FormatField(s.Width), FormatField(s.Height), s.Rotation)
+	}
+	return fmt.Sprintf("%s: %s×%s", s.Name, FormatField(s.Width), FormatField(s.Height))
+}
+
+// FormatField formats an angular field in degrees or arcminutes
+func FormatField(degrees float64) string {
+	if degrees < 1 {
+		return fmt.Sprintf("%.0f'", degrees*60)
+	}
+	return fmt.Sprintf("%.1f°", degrees)
+}
+
+// sensorField returns the angle in degrees subtended by a sensor dimension
+func sensorField(sizeMM, focalLength float64) float64 {
+	return 2 * math.Atan(sizeMM/(2*focalLength)) * 180 / math.Pi
+}
@@ -0,0 +1,54 @@
+package optics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEyepieceSetup(t *testing.T) {
+	scope := Telescope{Name: "C8", FocalLength: 2032, Aperture: 203}
+	eyepiece := Eyepiece{Name: "25mm Plössl", FocalLength: 25, ApparentFOV: 52}
+
+	s := EyepieceSetup(scope, eyepiece)
+	if math.Abs(s.Magnification-81.28) > 0.01 {
+		t.Errorf("magnification = %.2f, want 81.28", s.Magnification)
+	}
+	if math.Abs(s.Width-0.64) > 0.01 {
+		t.Errorf("true field = %.3f°, want 0.64°", s.Width)
+	}
+	if math.Abs(s.ExitPupil-2.50) > 0.01 {
+		t.Errorf("exit pupil = %.2fmm, want 2.50mm", s.ExitPupil)
+	}
+	if !s.Circular {
+		t.Error("eyepiece field should be circular")
+	}
+}
+
+func TestCameraSetup(t *testing.T) {
+	scope := Telescope{Name: "80ED", FocalLength: 480, Aperture: 80}
+	camera := Camera{Name: "APS-C", PixelSize: 3.76, Width: 6248, Height: 4176}
+
+	s := CameraSetup(scope, camera)
+	if math.Abs(s.Width-2.80) > 0.01 || math.Abs(s.Height-1.87) > 0.01 {
+		t.Errorf("field = %.2f°×%.2f°, want 2.80°×1.87°", s.Width, s.Height)
+	}
+	if s.Circular || s.Magnification != 0 {
+		t.Error("camera frame should be rectangular without magnification")
+	}
+}
+
+func TestSetupsSkipsIncompleteEquipment(t *testing.T) {
+	eq := Equipment{
+		Telescopes: []Telescope{{Name: "C8", FocalLength: 2032, Aperture: 203}, {Name: "broken"}},
+		Eyepieces:  []Eyepiece{{Name: "25mm", FocalLength: 25, ApparentFOV: 52}},
+		Finders:    []Finder{{Name: "8x50", Magnification: 8, Aperture: 50, FOV: 6}},
+	}
+
+	setups := eq.Setups()
+	if len(setups) != 2 {
+		t.Fatalf("got %d setups, want 2", len(setups))
+	}
+	if setups[1].Name != "8x50" || math.Abs(setups[1].ExitPupil-6.25) > 0.01 {
+		t.Errorf("unexpected finder setup %+v", setups[1])
+	}
+}
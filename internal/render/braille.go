@@ -117,9 +117,8 @@ func RenderStarsBraille(bc *BrailleCanvas, stars []catalog.Star, centerAlt, cent
 		px := int((x + 1.0) * float64(pixelWidth) / 2.0)
 		py := int((1.0 - y) * float64(pixelHeight) / 2.0)
 
-		// Get color for spectral type
-		color := getColorForSpectralType(star.SpectralType)
-		style := lipgloss.NewStyle().Foreground(color)
+		// Get color from B-V index or spectral type
+		style := lipgloss.NewStyle().Foreground(StarColor(star))
 
 		// For brighter stars, set multiple pixels
 		if star.Magnitude < 1.0 {
@@ -1,71 +1,263 @@
 package render
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
 type Cell struct {
-	Char  rune
-	Style lipgloss.Style
+	Char       rune
+	Background bool   // Grid and line cells that labels may cover
+	style      uint32 // Index into the canvas style table, 0 is unstyled
 }
 
+// styleKey is the comparable subset of a lipgloss.Style that the canvas
+// uses. Cells with equal keys are merged into a single ANSI run.
+type styleKey struct {
+	fg, bg                                         lipgloss.TerminalColor
+	bold, faint, italic, underline, reverse, blink bool
+}
+
+// styleEntry caches the escape sequences that open and close a style
+type styleEntry struct {
+	prefix string
+	suffix string
+}
+
+// styleMarker is rendered through lipgloss once per style to capture the
+// surrounding escape sequences. It is a private-use rune that never appears
+// on the canvas.
+const styleMarker = "\uE000"
+
 type Canvas struct {
 	Width  int
 	Height int
 	Cells  [][]Cell
+
+	styles      []styleEntry
+	styleKeys   []styleKey // Key of each style table entry
+	styleIndex  map[styleKey]uint32
+	staleStyles bool                   // Sky changed; start the table over at the next Clear
+	sky         lipgloss.TerminalColor // Background for cells without their own
+
+	// Previous frame, used to skip re-rendering unchanged rows
+	prevCells [][]Cell
+	lines     []string
+	blank     []Cell
 }
 
 func NewCanvas(width, height int) *Canvas {
 	cells := make([][]Cell, height)
+	prevCells := make([][]Cell, height)
+	blank := make([]Cell, width)
+	for j := range blank {
+		blank[j] = Cell{Char: ' '}
+	}
 	for i := range cells {
 		cells[i] = make([]Cell, width)
-		for j := range cells[i] {
-			cells[i][j] = Cell{
-				Char:  ' ',
-				Style: lipgloss.NewStyle(),
-			}
-		}
+		copy(cells[i], blank)
+		prevCells[i] = make([]Cell, width)
 	}
 
 	return &Canvas{
-		Width:  width,
-		Height: height,
-		Cells:  cells,
+		Width:      width,
+		Height:     height,
+		Cells:      cells,
+		styles:     []styleEntry{{}},
+		styleKeys:  []styleKey{{}},
+		styleIndex: make(map[styleKey]uint32),
+		prevCells:  prevCells,
+		lines:      make([]string, height),
+		blank:      blank,
+	}
+}
+
+// maxStyles bounds the style table. Star colors follow extinction and the
+// sky follows the Sun, so a long time-lapse keeps producing new styles; once
+// the table grows past this it starts over at the next Clear.
+const maxStyles = 4096
+
+// SetSkyColor sets the background painted behind every cell. It takes effect
+// from the next Clear, which also drops the styles built on the old sky.
+func (c *Canvas) SetSkyColor(color lipgloss.TerminalColor) {
+	if color == c.sky {
+		return
 	}
+	c.sky = color
+	c.staleStyles = true
 }
 
 func (c *Canvas) Clear() {
+	if c.staleStyles || len(c.styles) > maxStyles {
+		c.resetStyles()
+	}
 	for y := 0; y < c.Height; y++ {
-		for x := 0; x < c.Width; x++ {
-			c.Cells[y][x] = Cell{
-				Char:  ' ',
-				Style: lipgloss.NewStyle(),
-			}
-		}
+		copy(c.Cells[y], c.blank)
 	}
 }
 
+// resetStyles empties the style table, keeping only the blank sky cell.
+// Cached rows refer to the old table, so they are rendered again.
+func (c *Canvas) resetStyles() {
+	c.styles = c.styles[:1]
+	c.styleKeys = c.styleKeys[:1]
+	clear(c.styleIndex)
+	c.staleStyles = false
+
+	var id uint32
+	if c.sky != nil {
+		id = c.intern(lipgloss.NewStyle())
+	}
+	for j := range c.blank {
+		c.blank[j].style = id
+	}
+	clear(c.lines)
+}
+
 func (c *Canvas) Set(x, y int, char rune, style lipgloss.Style) {
 	if x >= 0 && x < c.Width && y >= 0 && y < c.Height {
 		c.Cells[y][x] = Cell{
 			Char:  char,
-			Style: style,
+			style: c.intern(style),
+		}
+	}
+}
+
+// SetBackground draws a cell that belongs to a background layer such as the
+// grid or constellation lines. Labels are allowed to cover these cells.
+func (c *Canvas) SetBackground(x, y int, char rune, style lipgloss.Style) {
+	if x >= 0 && x < c.Width && y >= 0 && y < c.Height {
+		c.Cells[y][x] = Cell{
+			Char:       char,
+			Background: true,
+			style:      c.intern(style),
+		}
+	}
+}
+
+// DrawLayer copies the drawn cells of a same-sized layer canvas onto this
+// one as background cells. Cells already holding foreground content, such
+// as stars, are kept.
+func (c *Canvas) DrawLayer(layer *Canvas) {
+	if layer.Width != c.Width || layer.Height != c.Height {
+		return
+	}
+
+	for y := 0; y < c.Height; y++ {
+		for x, cell := range layer.Cells[y] {
+			if cell.Char == ' ' {
+				continue
+			}
+			if target := c.Cells[y][x]; target.Char != ' ' && !target.Background {
+				continue
+			}
+			c.Cells[y][x] = Cell{
+				Char:       cell.Char,
+				Background: true,
+				style:      c.internKey(layer.styleKeys[cell.style]),
+			}
+		}
+	}
+}
+
+// intern returns the style table index for a style, adding it on first use
+func (c *Canvas) intern(style lipgloss.Style) uint32 {
+	key := styleKey{
+		fg:        style.GetForeground(),
+		bg:        style.GetBackground(),
+		bold:      style.GetBold(),
+		faint:     style.GetFaint(),
+		italic:    style.GetItalic(),
+		underline: style.GetUnderline(),
+		reverse:   style.GetReverse(),
+		blink:     style.GetBlink(),
+	}
+	return c.internKey(key)
+}
+
+// internKey returns the style table index for a style key
+func (c *Canvas) internKey(key styleKey) uint32 {
+	if _, unset := key.bg.(lipgloss.NoColor); unset && c.sky != nil {
+		key.bg = c.sky
+	}
+
+	if id, ok := c.styleIndex[key]; ok {
+		return id
+	}
+
+	// Rebuild from the key so layout properties never leak into the cache
+	rendered := lipgloss.NewStyle().
+		Foreground(key.fg).
+		Background(key.bg).
+		Bold(key.bold).
+		Faint(key.faint).
+		Italic(key.italic).
+		Underline(key.underline).
+		Reverse(key.reverse).
+		Blink(key.blink).
+		Render(styleMarker)
+
+	entry := styleEntry{}
+	if prefix, suffix, ok := strings.Cut(rendered, styleMarker); ok {
+		entry.prefix = prefix
+		entry.suffix = suffix
+	}
+
+	id := uint32(len(c.styles))
+	c.styles = append(c.styles, entry)
+	c.styleKeys = append(c.styleKeys, key)
+	c.styleIndex[key] = id
+	return id
+}
+
+// PlainText returns the canvas characters without styling
+func (c *Canvas) PlainText() string {
+	var sb strings.Builder
+	for y := 0; y < c.Height; y++ {
+		for _, cell := range c.Cells[y] {
+			sb.WriteRune(cell.Char)
 		}
+		sb.WriteRune('\n')
 	}
+	return sb.String()
 }
 
+// Render returns the canvas as a string. Runs of cells sharing a style are
+// emitted as a single styled span, and rows that haven't changed since the
+// last call are reused as-is.
 func (c *Canvas) Render() string {
 	var sb strings.Builder
 	for y := 0; y < c.Height; y++ {
-		for x := 0; x < c.Width; x++ {
-			cell := c.Cells[y][x]
-			sb.WriteString(cell.Style.Render(string(cell.Char)))
+		if c.lines[y] == "" || !slices.Equal(c.Cells[y], c.prevCells[y]) {
+			c.lines[y] = c.renderRow(c.Cells[y])
+			copy(c.prevCells[y], c.Cells[y])
 		}
+
+		sb.WriteString(c.lines[y])
 		if y < c.Height-1 {
 			sb.WriteRune('\n')
 		}
 	}
 	return sb.String()
 }
+
+// renderRow builds one row, merging adjacent cells with the same style
+func (c *Canvas) renderRow(row []Cell) string {
+	var sb strings.Builder
+	for x := 0; x < len(row); {
+		style := c.styles[row[x].style]
+
+		sb.WriteString(style.prefix)
+		end := x
+		for end < len(row) && row[end].style == row[x].style {
+			sb.WriteRune(row[end].Char)
+			end++
+		}
+		sb.WriteString(style.suffix)
+
+		x = end
+	}
+	return sb.String()
+}
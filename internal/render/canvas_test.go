@@ -0,0 +1,158 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// fillCanvas draws a frame resembling a busy sky: sparse coloured stars on
+// a dotted grid, shifted by offset so consecutive frames differ
+func fillCanvas(c *Canvas, offset int) {
+	grid := lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
+	colors := []lipgloss.Color{"27", "75", "231", "230", "229", "214", "196"}
+
+	c.Clear()
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			switch {
+			case (x*7+y*13+offset)%23 == 0:
+				style := lipgloss.NewStyle().Foreground(colors[(x+y)%len(colors)]).Bold(x%2 == 0)
+				c.Set(x, y, '●', style)
+			case x%10 == 0 || y%5 == 0:
+				c.SetBackground(x, y, '·', grid)
+			}
+		}
+	}
+}
+
+// renderPerCell is the previous rendering strategy, kept for comparison
+func renderPerCell(c *Canvas, styles [][]lipgloss.Style) string {
+	var sb strings.Builder
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			sb.WriteString(styles[y][x].Render(string(c.Cells[y][x].Char)))
+		}
+		if y < c.Height-1 {
+			sb.WriteRune('\n')
+		}
+	}
+	return sb.String()
+}
+
+func withProfile(b testing.TB, profile termenv.Profile) {
+	previous := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(profile)
+	b.Cleanup(func() { lipgloss.SetColorProfile(previous) })
+}
+
+func TestCanvasRenderMergesRuns(t *testing.T) {
+	withProfile(t, termenv.ANSI256)
+
+	c := NewCanvas(6, 1)
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	for x := 0; x < 4; x++ {
+		c.Set(x, 0, 'x', red)
+	}
+
+	out := c.Render()
+	if got := strings.Count(out, "\x1b[0m"); got != 1 {
+		t.Errorf("expected one reset for a single styled run, got %d in %q", got, out)
+	}
+	if !strings.Contains(out, "xxxx") {
+		t.Errorf("run characters not contiguous: %q", out)
+	}
+}
+
+func TestCanvasRenderReflectsChanges(t *testing.T) {
+	c := NewCanvas(5, 2)
+	c.Set(1, 1, '*', lipgloss.NewStyle())
+	if got := c.Render(); got != "     \n *   " {
+		t.Fatalf("unexpected first frame %q", got)
+	}
+
+	c.Clear()
+	c.Set(3, 0, '+', lipgloss.NewStyle())
+	if got := c.Render(); got != "   + \n     " {
+		t.Fatalf("cached rows were not invalidated: %q", got)
+	}
+}
+
+func TestCanvasStyleTableBounded(t *testing.T) {
+	withProfile(t, termenv.TrueColor)
+
+	c := NewCanvas(8, 2)
+	for i := 0; i < 200; i++ {
+		// A sky brightening with the Sun, and a star reddening with extinction
+		c.SetSkyColor(lipgloss.Color(fmt.Sprintf("#0000%02x", i)))
+		c.Clear()
+		c.Set(1, 1, '*', lipgloss.NewStyle().Foreground(lipgloss.Color(fmt.Sprintf("#ff%02x00", i))))
+		c.Render()
+	}
+	if got := len(c.styles); got > 3 {
+		t.Errorf("style table holds %d entries after 200 sky changes, want at most 3", got)
+	}
+
+	// The last sky must still be painted once the table has started over
+	if out := c.Render(); !strings.Contains(out, "48;2;0;0;199") {
+		t.Errorf("latest sky color missing from %q", out)
+	}
+
+	for i := 0; i <= maxStyles; i++ {
+		c.Set(0, 0, '*', lipgloss.NewStyle().Foreground(lipgloss.Color(fmt.Sprintf("#%06x", i))))
+	}
+	c.Clear()
+	if got := len(c.styles); got > 2 {
+		t.Errorf("style table holds %d entries after growing past the limit, want it reset", got)
+	}
+}
+
+func BenchmarkCanvasRenderPerCell(b *testing.B) {
+	withProfile(b, termenv.TrueColor)
+
+	c := NewCanvas(300, 80)
+	fillCanvas(c, 0)
+
+	// Rebuild the per-cell styles the old canvas stored
+	styles := make([][]lipgloss.Style, c.Height)
+	grid := lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
+	for y := range styles {
+		styles[y] = make([]lipgloss.Style, c.Width)
+		for x := range styles[y] {
+			styles[y][x] = lipgloss.NewStyle()
+			if c.Cells[y][x].Background {
+				styles[y][x] = grid
+			}
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = renderPerCell(c, styles)
+	}
+}
+
+func BenchmarkCanvasRenderChanged(b *testing.B) {
+	withProfile(b, termenv.TrueColor)
+
+	c := NewCanvas(300, 80)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fillCanvas(c, i)
+		_ = c.Render()
+	}
+}
+
+func BenchmarkCanvasRenderUnchanged(b *testing.B) {
+	withProfile(b, termenv.TrueColor)
+
+	c := NewCanvas(300, 80)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fillCanvas(c, 0)
+		_ = c.Render()
+	}
+}
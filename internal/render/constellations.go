@@ -1,11 +1,16 @@
 package render
 
 import (
+	"math"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/craigderington/skyterm/internal/catalog"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
-// RenderConstellations draws constellation lines on the canvas
+// RenderConstellations draws constellation lines on the canvas. Lines follow
+// the great circle between their stars and are clipped at the viewport edge,
+// so a constellation stays drawn while only part of it is in view.
 func RenderConstellations(canvas *Canvas, stars []catalog.Star, constellations []catalog.Constellation, centerAlt, centerAz, fov float64) {
 	// Create a map of star names to stars for quick lookup
 	starMap := make(map[string]catalog.Star)
@@ -13,8 +18,11 @@ func RenderConstellations(canvas *Canvas, stars []catalog.Star, constellations [
 		starMap[star.Name] = star
 	}
 
-	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-	lineChar := '─'
+	lineStyle := lipgloss.NewStyle().Foreground(theme.Current().ConstellationLine)
+	basis := newViewBasis(centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+
+	// Arcs are drawn as short chords so curvature is preserved at wide FOV
+	maxStep := math.Min(2.0, fov/30.0) * math.Pi / 180.0
 
 	for _, constellation := range constellations {
 		for _, line := range constellation.Lines {
@@ -30,34 +38,115 @@ func RenderConstellations(canvas *Canvas, stars []catalog.Star, constellations [
 				continue
 			}
 
-			// Project both stars
-			x1, y1, visible1 := Project(star1.Altitude, star1.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
-			x2, y2, visible2 := Project(star2.Altitude, star2.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+			drawArc(canvas, basis,
+				horizontalVector(star1.Altitude, star1.Azimuth),
+				horizontalVector(star2.Altitude, star2.Azimuth),
+				maxStep, lineStyle)
+		}
+	}
+}
 
-			// Only draw if both stars are visible
-			if !visible1 || !visible2 {
-				continue
-			}
+// drawArc draws the great-circle arc between two directions
+func drawArc(canvas *Canvas, basis viewBasis, from, to vec3, maxStep float64, style lipgloss.Style) {
+	angle := math.Acos(math.Max(-1.0, math.Min(1.0, from.dot(to))))
+	if angle == 0 {
+		return
+	}
+
+	steps := int(math.Ceil(angle / maxStep))
+	if steps < 1 {
+		steps = 1
+	}
+
+	// Orthonormal basis in the plane of the arc
+	perp := to.add(from.scale(-from.dot(to))).normalize()
+
+	prevX, prevY, prevFront := basis.project(from)
+	for i := 1; i <= steps; i++ {
+		t := angle * float64(i) / float64(steps)
+		point := from.scale(math.Cos(t)).add(perp.scale(math.Sin(t)))
+
+		x, y, front := basis.project(point)
+		if prevFront && front {
+			drawClippedSegment(canvas, prevX, prevY, x, y, style)
+		}
+		prevX, prevY, prevFront = x, y, front
+	}
+}
+
+// drawClippedSegment clips a segment to the canvas (Liang–Barsky) and draws
+// it with a glyph matching its direction
+func drawClippedSegment(canvas *Canvas, x1, y1, x2, y2 float64, style lipgloss.Style) {
+	dx := x2 - x1
+	dy := y2 - y1
+
+	t0, t1 := 0.0, 1.0
+	maxX := float64(canvas.Width) - 0.001
+	maxY := float64(canvas.Height) - 0.001
 
-			// Draw line using Bresenham's algorithm
-			drawLine(canvas, x1, y1, x2, y2, lineChar, lineStyle)
+	clip := func(p, q float64) bool {
+		if p == 0 {
+			return q >= 0
 		}
+		r := q / p
+		if p < 0 {
+			if r > t1 {
+				return false
+			}
+			if r > t0 {
+				t0 = r
+			}
+		} else {
+			if r < t0 {
+				return false
+			}
+			if r < t1 {
+				t1 = r
+			}
+		}
+		return true
+	}
+
+	if !clip(-dx, x1) || !clip(dx, maxX-x1) || !clip(-dy, y1) || !clip(dy, maxY-y1) {
+		return
+	}
+
+	drawLine(canvas,
+		int(x1+t0*dx), int(y1+t0*dy),
+		int(x1+t1*dx), int(y1+t1*dy),
+		lineGlyph(dx, dy), style)
+}
+
+// lineGlyph picks a box-drawing character for a segment direction. Terminal
+// cells are about twice as tall as they are wide, so dy is weighted double.
+func lineGlyph(dx, dy float64) rune {
+	angle := math.Atan2(math.Abs(dy)*2, math.Abs(dx)) * 180.0 / math.Pi
+
+	switch {
+	case angle < 22.5:
+		return '─'
+	case angle > 67.5:
+		return '│'
+	case (dx > 0) == (dy > 0):
+		return '╲'
+	default:
+		return '╱'
 	}
 }
 
-// RenderConstellationLabels draws constellation name labels
-func RenderConstellationLabels(canvas *Canvas, stars []catalog.Star, labels []catalog.ConstellationLabel, centerAlt, centerAz, fov float64) {
+// RenderConstellationLabels queues constellation name labels
+func RenderConstellationLabels(canvas *Canvas, labels *LabelLayout, stars []catalog.Star, names []catalog.ConstellationLabel, centerAlt, centerAz, fov float64) {
 	starMap := make(map[string]catalog.Star)
 	for _, star := range stars {
 		starMap[star.Name] = star
 	}
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("51")). // Bright cyan
+		Foreground(theme.Current().ConstellationLabel).
 		Bold(true)
 
-	for _, label := range labels {
-		star, ok := starMap[label.StarName]
+	for i, name := range names {
+		star, ok := starMap[name.StarName]
 		if !ok {
 			continue
 		}
@@ -67,26 +156,14 @@ func RenderConstellationLabels(canvas *Canvas, stars []catalog.Star, labels []ca
 			continue
 		}
 
-		// Offset label to the right and slightly up from the star
-		labelX := x + 2
-		labelY := y - 1
-
-		// Check if label position is valid
-		if labelY < 0 || labelY >= canvas.Height {
-			// Try placing it below instead
-			labelY = y + 1
-			if labelY >= canvas.Height {
-				continue
-			}
-		}
-
-		// Draw label, checking each character position
-		for i, ch := range label.Name {
-			xPos := labelX + i
-			if xPos >= 0 && xPos < canvas.Width {
-				canvas.Set(xPos, labelY, ch, labelStyle)
-			}
-		}
+		labels.Add(Label{
+			Text:     name.Name,
+			X:        x,
+			Y:        y,
+			Priority: PriorityConstellation,
+			Rank:     float64(i),
+			Style:    labelStyle,
+		})
 	}
 }
 
@@ -112,8 +189,8 @@ func drawLine(canvas *Canvas, x1, y1, x2, y2 int, char rune, style lipgloss.Styl
 	for {
 		// Set pixel, but don't overwrite stars
 		cell := canvas.Cells[y][x]
-		if cell.Char == ' ' || cell.Char == char {
-			canvas.Set(x, y, char, style)
+		if cell.Char == ' ' || cell.Background {
+			canvas.SetBackground(x, y, char, style)
 		}
 
 		if x == x2 && y == y2 {
@@ -0,0 +1,73 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestDrawClippedSegmentAcrossViewport(t *testing.T) {
+	canvas := NewCanvas(10, 5)
+
+	// Both endpoints are off screen; the visible middle must still be drawn
+	drawClippedSegment(canvas, -20, 2, 30, 2, lipgloss.NewStyle())
+
+	for x := 0; x < canvas.Width; x++ {
+		if canvas.Cells[2][x].Char != '─' {
+			t.Fatalf("cell %d not drawn: %q", x, rowText(canvas, 2))
+		}
+	}
+}
+
+func TestDrawClippedSegmentOutside(t *testing.T) {
+	canvas := NewCanvas(10, 5)
+	drawClippedSegment(canvas, -20, -3, 30, -1, lipgloss.NewStyle())
+
+	for y := 0; y < canvas.Height; y++ {
+		for x := 0; x < canvas.Width; x++ {
+			if canvas.Cells[y][x].Char != ' ' {
+				t.Fatalf("segment above the canvas drew at %d,%d", x, y)
+			}
+		}
+	}
+}
+
+func TestLineGlyph(t *testing.T) {
+	tests := []struct {
+		dx, dy float64
+		want   rune
+	}{
+		{10, 0, '─'},
+		{0, 5, '│'},
+		{4, 2, '╲'},
+		{-4, -2, '╲'},
+		{4, -2, '╱'},
+		{-4, 2, '╱'},
+	}
+
+	for _, tt := range tests {
+		if got := lineGlyph(tt.dx, tt.dy); got != tt.want {
+			t.Errorf("lineGlyph(%v, %v) = %q, want %q", tt.dx, tt.dy, got, tt.want)
+		}
+	}
+}
+
+func TestRenderConstellationsPartiallyVisible(t *testing.T) {
+	canvas := NewCanvas(40, 20)
+	basis := newViewBasis(45, 180, 30, canvas.Width, canvas.Height)
+
+	// One end near the center, the other far outside the field of view
+	drawArc(canvas, basis, horizontalVector(45, 180), horizontalVector(45, 240), 0.01, lipgloss.NewStyle())
+
+	drawn := 0
+	for y := 0; y < canvas.Height; y++ {
+		for x := 0; x < canvas.Width; x++ {
+			if canvas.Cells[y][x].Char != ' ' {
+				drawn++
+			}
+		}
+	}
+	if drawn == 0 {
+		t.Error("partially visible line was not drawn")
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/astro"
 	"github.com/craigderington/skyterm/internal/catalog"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
 // RenderDeepSkyObjects draws Messier objects on the canvas
-func RenderDeepSkyObjects(canvas *Canvas, objects []catalog.MessierObject, centerAlt, centerAz, fov, magLimit float64) {
+func RenderDeepSkyObjects(canvas *Canvas, objects []catalog.MessierObject, centerAlt, centerAz, fov float64, vis Visibility) {
 	// Object type symbols and colors
 	typeStyles := map[string]struct {
 		char  rune
@@ -22,15 +24,11 @@ func RenderDeepSkyObjects(canvas *Canvas, objects []catalog.MessierObject, cente
 		"Planetary Nebula":    {'◎', lipgloss.Color("48")},  // Cyan
 	}
 
+	basis := newViewBasis(centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+
 	for _, obj := range objects {
 		// Skip if too dim
-		if obj.Magnitude > magLimit+3 {
-			continue
-		}
-
-		// Project to screen
-		x, y, visible := Project(obj.Altitude, obj.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
-		if !visible {
+		if !vis.DeepSkyVisible(obj.Magnitude, obj.Altitude, obj.Azimuth) {
 			continue
 		}
 
@@ -42,21 +40,56 @@ func RenderDeepSkyObjects(canvas *Canvas, objects []catalog.MessierObject, cente
 			style.color = lipgloss.Color("245")
 		}
 
-		objStyle := lipgloss.NewStyle().Foreground(style.color)
+		objStyle := lipgloss.NewStyle().Foreground(theme.Current().Color(style.color))
+
+		// Outline large objects at their true size, even when the center is
+		// just off screen
+		extent := obj.MajorAxis / 60.0 * basis.cellsPerDegree()
+		if extent >= minOutlineCells &&
+			astro.AngularSeparation(obj.Altitude, obj.Azimuth, centerAlt, centerAz) < fov+obj.MajorAxis/60.0 {
+			drawOutline(canvas, basis, deepSkyOutline(obj), fov, objStyle)
+		}
+
+		// Project to screen
+		x, y, visible := Project(obj.Altitude, obj.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+		if !visible {
+			continue
+		}
 
 		canvas.Set(x, y, style.char, objStyle)
 	}
 }
 
-// RenderDeepSkyLabels draws labels for Messier objects
-func RenderDeepSkyLabels(canvas *Canvas, objects []catalog.MessierObject, centerAlt, centerAz, fov, magLimit float64) {
+// minOutlineCells is the projected size at which an object's ellipse is
+// drawn around its symbol
+const minOutlineCells = 4.0
+
+// deepSkyOutline traces an object's ellipse. Position angles are measured
+// from celestial north through east; the parallactic angle turns north
+// relative to the zenith, and east lies counterclockwise of north.
+func deepSkyOutline(obj catalog.MessierObject) []vec3 {
+	minor := obj.MinorAxis
+	if minor <= 0 {
+		minor = obj.MajorAxis
+	}
+
+	return ellipseOutline(
+		horizontalVector(obj.Altitude, obj.Azimuth),
+		obj.MajorAxis/120.0, minor/120.0,
+		obj.ParallacticAngle-obj.PositionAngle,
+		48,
+	)
+}
+
+// RenderDeepSkyLabels queues labels for Messier objects
+func RenderDeepSkyLabels(canvas *Canvas, labels *LabelLayout, objects []catalog.MessierObject, centerAlt, centerAz, fov float64, vis Visibility) {
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("magenta")).
+		Foreground(theme.Current().DeepSkyLabel).
 		Faint(true)
 
 	for _, obj := range objects {
 		// Skip if too dim
-		if obj.Magnitude > magLimit+3 {
+		if !vis.DeepSkyVisible(obj.Magnitude, obj.Altitude, obj.Azimuth) {
 			continue
 		}
 
@@ -66,23 +99,13 @@ func RenderDeepSkyLabels(canvas *Canvas, objects []catalog.MessierObject, center
 			continue
 		}
 
-		// Create label (M number)
-		label := fmt.Sprintf("M%d", obj.Number)
-
-		// Position label
-		labelX := x + 2
-		labelY := y
-
-		if labelY < 0 || labelY >= canvas.Height {
-			continue
-		}
-
-		// Draw label
-		for i, ch := range label {
-			xPos := labelX + i
-			if xPos >= 0 && xPos < canvas.Width {
-				canvas.Set(xPos, labelY, ch, labelStyle)
-			}
-		}
+		labels.Add(Label{
+			Text:     fmt.Sprintf("M%d", obj.Number),
+			X:        x,
+			Y:        y,
+			Priority: PriorityDeepSky,
+			Rank:     vis.ApparentMagnitude(obj.Magnitude, obj.Altitude),
+			Style:    labelStyle,
+		})
 	}
 }
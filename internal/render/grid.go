@@ -2,23 +2,49 @@ package render
 
 import (
 	"fmt"
+	"math"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
 // RenderGrid draws a coordinate grid overlay showing altitude and azimuth
 func RenderGrid(canvas *Canvas, centerAlt, centerAz, fov float64) {
-	gridStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
-	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("242"))
+	th := theme.Current()
+	gridStyle := lipgloss.NewStyle().Foreground(th.Grid)
+	labelStyle := lipgloss.NewStyle().Foreground(th.GridLabel)
+
+	// Line spacing shrinks with the field so a few lines stay in view;
+	// dots are sampled three times per spacing as at the default zoom
+	spacing := gridSpacing(fov)
+	sample := spacing / 3
+	format := gridLabelFormat(spacing)
+
+	// Only lines near the view are walked, which keeps narrow fields cheap
+	altMin := math.Max(-90.0, centerAlt-fov)
+	altMax := math.Min(90.0, centerAlt+fov)
+	azSpan := 360.0
+	if edge := math.Abs(centerAlt) + fov; edge < 89.0 {
+		azSpan = math.Min(360.0, 2*fov/math.Cos(edge*math.Pi/180.0))
+	}
+	azMin := centerAz - azSpan/2
+	azMax := centerAz + azSpan/2
+	if azSpan >= 360.0 {
+		azMin, azMax = 0.0, 360.0-sample/2
+	}
 
 	// Draw altitude lines (horizontal)
-	for alt := -90.0; alt <= 90.0; alt += 15.0 {
+	for i := math.Ceil(altMin / spacing); i*spacing <= altMax; i++ {
+		alt := i * spacing
+
 		// Sample points along this altitude circle
-		for az := 0.0; az < 360.0; az += 5.0 {
+		for j := math.Ceil(azMin / sample); j*sample <= azMax; j++ {
+			az := math.Mod(j*sample+360.0, 360.0)
 			x, y, visible := Project(alt, az, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
 			if visible {
 				cell := canvas.Cells[y][x]
 				if cell.Char == ' ' {
-					canvas.Set(x, y, '·', gridStyle)
+					canvas.SetBackground(x, y, '·', gridStyle)
 				}
 			}
 		}
@@ -27,24 +53,27 @@ func RenderGrid(canvas *Canvas, centerAlt, centerAz, fov float64) {
 		az := centerAz
 		x, y, visible := Project(alt, az, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
 		if visible {
-			label := fmt.Sprintf("%.0f°", alt)
+			label := fmt.Sprintf(format, alt)
 			for i, ch := range label {
 				if x+i < canvas.Width {
-					canvas.Set(x+i, y, ch, labelStyle)
+					canvas.SetBackground(x+i, y, ch, labelStyle)
 				}
 			}
 		}
 	}
 
 	// Draw azimuth lines (vertical from horizon to zenith)
-	for az := 0.0; az < 360.0; az += 15.0 {
+	for i := math.Ceil(azMin / spacing); i*spacing <= azMax; i++ {
+		az := math.Mod(i*spacing+360.0, 360.0)
+
 		// Sample points along this azimuth meridian
-		for alt := -90.0; alt <= 90.0; alt += 5.0 {
+		for j := math.Ceil(altMin / sample); j*sample <= altMax; j++ {
+			alt := j * sample
 			x, y, visible := Project(alt, az, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
 			if visible {
 				cell := canvas.Cells[y][x]
 				if cell.Char == ' ' {
-					canvas.Set(x, y, '·', gridStyle)
+					canvas.SetBackground(x, y, '·', gridStyle)
 				}
 			}
 		}
@@ -53,10 +82,10 @@ func RenderGrid(canvas *Canvas, centerAlt, centerAz, fov float64) {
 		alt := 0.0
 		x, y, visible := Project(alt, az, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
 		if visible {
-			label := fmt.Sprintf("%.0f°", az)
+			label := fmt.Sprintf(format, az)
 			for i, ch := range label {
 				if y+i < canvas.Height {
-					canvas.Set(x, y+i, ch, labelStyle)
+					canvas.SetBackground(x, y+i, ch, labelStyle)
 				}
 			}
 		}
@@ -71,7 +100,7 @@ func RenderGrid(canvas *Canvas, centerAlt, centerAz, fov float64) {
 	}
 
 	cardinalStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("yellow")).
+		Foreground(th.Cardinal).
 		Bold(true)
 
 	for az, label := range cardinals {
@@ -81,3 +110,28 @@ func RenderGrid(canvas *Canvas, centerAlt, centerAz, fov float64) {
 		}
 	}
 }
+
+// gridSpacings are the allowed grid line spacings in degrees
+var gridSpacings = []float64{15, 10, 5, 2, 1, 0.5, 0.25, 0.1, 0.05, 0.02}
+
+// gridSpacing picks the widest spacing that fits about four lines in view
+func gridSpacing(fov float64) float64 {
+	for _, s := range gridSpacings {
+		if s <= fov/4 {
+			return s
+		}
+	}
+	return gridSpacings[len(gridSpacings)-1]
+}
+
+// gridLabelFormat returns a label format with enough decimals for spacing
+func gridLabelFormat(spacing float64) string {
+	switch {
+	case spacing >= 1:
+		return "%.0f°"
+	case spacing >= 0.1:
+		return "%.1f°"
+	default:
+		return "%.2f°"
+	}
+}
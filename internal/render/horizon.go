@@ -0,0 +1,23 @@
+package render
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// horizonStep is the azimuth spacing in degrees of points on a horizon
+// profile outline
+const horizonStep = 1.0
+
+// RenderHorizon draws a site's horizon profile as a line all the way round
+func RenderHorizon(canvas *Canvas, profile astro.HorizonProfile, centerAlt, centerAz, fov float64) {
+	basis := newViewBasis(centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+	style := lipgloss.NewStyle().Foreground(theme.Current().Cardinal)
+
+	var outline []vec3
+	for az := 0.0; az <= 360; az += horizonStep {
+		outline = append(outline, horizontalVector(profile.AltitudeAt(az), az))
+	}
+	drawOutline(canvas, basis, outline, fov, style)
+}
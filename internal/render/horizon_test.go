@@ -0,0 +1,38 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/craigderington/skyterm/internal/astro"
+)
+
+func TestRenderHorizonFollowsProfile(t *testing.T) {
+	flat := astro.NewHorizonProfile([]astro.HorizonPoint{{Azimuth: 0, Altitude: 0}})
+
+	// A flat horizon is a great circle, straight across a level view
+	canvas := NewCanvas(80, 40)
+	RenderHorizon(canvas, flat, 0, 180, 60)
+	row := rowText(canvas, 20)
+	if strings.Contains(row, " ") {
+		t.Fatalf("flat horizon has gaps: %q", row)
+	}
+
+	// A hill to the south rises into the upper half of the view
+	hill := astro.NewHorizonProfile([]astro.HorizonPoint{
+		{Azimuth: 150, Altitude: 0},
+		{Azimuth: 180, Altitude: 15},
+		{Azimuth: 210, Altitude: 0},
+	})
+	canvas = NewCanvas(80, 40)
+	RenderHorizon(canvas, hill, 0, 180, 60)
+	above := false
+	for y := 0; y < 19; y++ {
+		if strings.TrimSpace(rowText(canvas, y)) != "" {
+			above = true
+		}
+	}
+	if !above {
+		t.Error("hill not drawn above the level horizon")
+	}
+}
@@ -0,0 +1,149 @@
+package render
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Label priorities. Higher priorities claim canvas space first, so a planet
+// label is never pushed aside by a constellation name.
+const (
+	PriorityTrail = iota + 1
+	PriorityConstellation
+	PriorityDeepSky
+	PriorityStar
+	PriorityPlanet
+)
+
+// Label is a candidate text label anchored to an object on screen
+type Label struct {
+	Text     string
+	X, Y     int     // Screen position of the labelled object
+	Priority int     // One of the Priority* constants
+	Rank     float64 // Tie-breaker within a priority, lower wins (e.g. magnitude)
+	Style    lipgloss.Style
+}
+
+// labelAnchor is an offset of the label's first character from the object
+type labelAnchor struct {
+	dx, dy int
+	right  bool // Label extends to the left of the object when false
+}
+
+// labelAnchors lists the positions tried for each label, in order of preference
+var labelAnchors = []labelAnchor{
+	{dx: 2, dy: 0, right: true},   // Right
+	{dx: -2, dy: 0, right: false}, // Left
+	{dx: 1, dy: -1, right: true},  // Upper right
+	{dx: 1, dy: 1, right: true},   // Lower right
+	{dx: -1, dy: -1, right: false},
+	{dx: -1, dy: 1, right: false},
+	{dx: 2, dy: -2, right: true},
+	{dx: 2, dy: 2, right: true},
+}
+
+// LabelLayout collects labels from every layer and places them in a single
+// pass, so labels don't overwrite each other or the objects they describe
+type LabelLayout struct {
+	canvas   *Canvas
+	labels   []Label
+	occupied [][]bool
+}
+
+// NewLabelLayout creates an empty layout for the given canvas
+func NewLabelLayout(canvas *Canvas) *LabelLayout {
+	occupied := make([][]bool, canvas.Height)
+	for i := range occupied {
+		occupied[i] = make([]bool, canvas.Width)
+	}
+
+	return &LabelLayout{
+		canvas:   canvas,
+		occupied: occupied,
+	}
+}
+
+// Add queues a label for placement
+func (l *LabelLayout) Add(label Label) {
+	if label.Text == "" {
+		return
+	}
+	l.labels = append(l.labels, label)
+}
+
+// Draw places queued labels on the canvas, highest priority first.
+// Each label tries the alternative anchor positions in turn and is dropped
+// if none of them fits.
+func (l *LabelLayout) Draw() {
+	sort.SliceStable(l.labels, func(i, j int) bool {
+		if l.labels[i].Priority != l.labels[j].Priority {
+			return l.labels[i].Priority > l.labels[j].Priority
+		}
+		return l.labels[i].Rank < l.labels[j].Rank
+	})
+
+	// Reserve every labelled object so no label covers another's glyph
+	for _, label := range l.labels {
+		l.reserve(label.X, label.Y, 1)
+	}
+
+	for _, label := range l.labels {
+		width := utf8.RuneCountInString(label.Text)
+
+		for _, anchor := range labelAnchors {
+			x := label.X + anchor.dx
+			if !anchor.right {
+				x = label.X + anchor.dx - width + 1
+			}
+			y := label.Y + anchor.dy
+
+			if !l.fits(x, y, width) {
+				continue
+			}
+
+			i := 0
+			for _, ch := range label.Text {
+				l.canvas.Set(x+i, y, ch, label.Style)
+				i++
+			}
+			l.reserve(x, y, width)
+			break
+		}
+	}
+
+	l.labels = l.labels[:0]
+}
+
+// fits reports whether a label of the given width can be drawn at x, y
+// without leaving the canvas or covering anything but background
+func (l *LabelLayout) fits(x, y, width int) bool {
+	if y < 0 || y >= l.canvas.Height || x < 0 || x+width > l.canvas.Width {
+		return false
+	}
+
+	for i := x; i < x+width; i++ {
+		if l.occupied[y][i] {
+			return false
+		}
+		cell := l.canvas.Cells[y][i]
+		if cell.Char != ' ' && !cell.Background {
+			return false
+		}
+	}
+
+	return true
+}
+
+// reserve marks cells as taken
+func (l *LabelLayout) reserve(x, y, width int) {
+	if y < 0 || y >= l.canvas.Height {
+		return
+	}
+	for i := x; i < x+width; i++ {
+		if i >= 0 && i < l.canvas.Width {
+			l.occupied[y][i] = true
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func rowText(c *Canvas, y int) string {
+	var sb strings.Builder
+	for x := 0; x < c.Width; x++ {
+		sb.WriteRune(c.Cells[y][x].Char)
+	}
+	return sb.String()
+}
+
+func TestLabelLayoutPriority(t *testing.T) {
+	canvas := NewCanvas(20, 3)
+	labels := NewLabelLayout(canvas)
+
+	// Two objects side by side; only one label fits to the right of each,
+	// so the higher priority label must win the contested space
+	labels.Add(Label{Text: "Deneb", X: 2, Y: 1, Priority: PriorityStar})
+	labels.Add(Label{Text: "Mars", X: 5, Y: 1, Priority: PriorityPlanet})
+	labels.Draw()
+
+	if !strings.Contains(rowText(canvas, 1), "Mars") {
+		t.Errorf("planet label not placed at its preferred anchor: %q", rowText(canvas, 1))
+	}
+
+	// The star label must have moved instead of overwriting
+	found := false
+	for y := 0; y < canvas.Height; y++ {
+		if strings.Contains(rowText(canvas, y), "Deneb") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("star label dropped although alternative anchors were free")
+	}
+}
+
+func TestLabelLayoutKeepsGlyphs(t *testing.T) {
+	canvas := NewCanvas(10, 1)
+	canvas.Set(4, 0, '●', lipgloss.NewStyle())
+
+	labels := NewLabelLayout(canvas)
+	labels.Add(Label{Text: "Vega", X: 1, Y: 0, Priority: PriorityStar})
+	labels.Draw()
+
+	if canvas.Cells[0][4].Char != '●' {
+		t.Errorf("label overwrote a star glyph: %q", rowText(canvas, 0))
+	}
+}
+
+func TestLabelLayoutCoversBackground(t *testing.T) {
+	canvas := NewCanvas(10, 1)
+	for x := 0; x < canvas.Width; x++ {
+		canvas.SetBackground(x, 0, '·', lipgloss.NewStyle())
+	}
+
+	labels := NewLabelLayout(canvas)
+	labels.Add(Label{Text: "Vega", X: 0, Y: 0, Priority: PriorityStar})
+	labels.Draw()
+
+	if !strings.Contains(rowText(canvas, 0), "Vega") {
+		t.Errorf("label should cover grid cells: %q", rowText(canvas, 0))
+	}
+}
@@ -0,0 +1,36 @@
+package render
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/catalog"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// markerRank sorts the marker label ahead of every planet
+const markerRank = -100
+
+// RenderMarker draws a go-to marker and queues its label
+func RenderMarker(canvas *Canvas, labels *LabelLayout, marker *catalog.Marker, centerAlt, centerAz, fov float64) {
+	if marker == nil {
+		return
+	}
+
+	x, y, visible := Project(marker.Altitude, marker.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+	if !visible {
+		return
+	}
+
+	style := lipgloss.NewStyle().
+		Foreground(theme.Current().Overlay).
+		Bold(true)
+	canvas.Set(x, y, '⊕', style)
+
+	labels.Add(Label{
+		Text:     marker.Name,
+		X:        x,
+		Y:        y,
+		Priority: PriorityPlanet,
+		Rank:     markerRank,
+		Style:    style,
+	})
+}
@@ -0,0 +1,117 @@
+package render
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/catalog"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// Minimap inset size in cells. Braille dots are square at the usual 1:2
+// cell aspect, so the dome is 40×40 dots.
+const (
+	MinimapWidth  = 20
+	MinimapHeight = 10
+)
+
+const (
+	minimapRadius    = 15.0 // Horizon radius in dots, leaving room for N/E/S/W
+	minimapMagnitude = 3.0  // Faintest star plotted
+	minimapEdgeDots  = 24   // Samples along each viewport edge
+)
+
+// RenderMinimap draws a small all-sky dome in the top right corner of the
+// canvas: the horizon, cardinal points, bright stars, an outline of the
+// current view and, when selected is set, the selected object's position.
+// The dome is drawn as seen looking up, with north at the top and east on
+// the left. Canvases too small to hold the inset are left untouched.
+func RenderMinimap(canvas *Canvas, stars []catalog.Star, selectedAlt, selectedAz float64, selected bool, centerAlt, centerAz, fov float64) {
+	if canvas.Width < MinimapWidth*2 || canvas.Height < MinimapHeight*2 {
+		return
+	}
+
+	th := theme.Current()
+	dome := NewBrailleCanvas(MinimapWidth, MinimapHeight)
+
+	// Horizon circle
+	horizonStyle := lipgloss.NewStyle().Foreground(th.Grid)
+	for i := 0; i < 96; i++ {
+		angle := float64(i) / 96 * 2 * math.Pi
+		x, y := minimapDot(minimapRadius*math.Sin(angle), minimapRadius*math.Cos(angle))
+		dome.SetPixel(x, y, horizonStyle)
+	}
+
+	// Bright stars above the horizon
+	for _, star := range stars {
+		if star.Magnitude > minimapMagnitude || star.Altitude < 0 {
+			continue
+		}
+		style := lipgloss.NewStyle().Foreground(th.Color(StarColor(star)))
+		x, y := minimapPoint(horizontalVector(star.Altitude, star.Azimuth))
+		dome.SetPixel(x, y, style)
+	}
+
+	// Viewport outline, traced along the screen edges
+	basis := newViewBasis(centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+	overlayStyle := lipgloss.NewStyle().Foreground(th.Overlay)
+	w, h := float64(canvas.Width), float64(canvas.Height)
+	for i := 0; i <= minimapEdgeDots; i++ {
+		f := float64(i) / minimapEdgeDots
+		for _, p := range [][2]float64{{f * w, 0}, {w, f * h}, {(1 - f) * w, h}, {0, (1 - f) * h}} {
+			x, y := minimapPoint(basis.unproject(p[0], p[1]))
+			dome.SetPixel(x, y, overlayStyle)
+		}
+	}
+
+	// Selected object as a small cross
+	if selected && selectedAlt >= 0 {
+		style := lipgloss.NewStyle().Foreground(th.Warning).Bold(true)
+		x, y := minimapPoint(horizontalVector(selectedAlt, selectedAz))
+		for _, d := range [][2]int{{0, 0}, {-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			dome.SetPixel(x+d[0], y+d[1], style)
+		}
+	}
+
+	// Copy into the canvas, blanking the sky beneath the inset
+	left := canvas.Width - MinimapWidth
+	for y := 0; y < MinimapHeight; y++ {
+		for x := 0; x < MinimapWidth; x++ {
+			char := ' '
+			if dots := dome.PixelData[y][x]; dots != 0 {
+				char = rune(brailleBase + int(dots))
+			}
+			canvas.Set(left+x, y, char, dome.Styles[y][x])
+		}
+	}
+
+	cardinalStyle := lipgloss.NewStyle().Foreground(th.Cardinal).Bold(true)
+	canvas.Set(left+MinimapWidth/2, 0, 'N', cardinalStyle)
+	canvas.Set(left+MinimapWidth/2, MinimapHeight-1, 'S', cardinalStyle)
+	canvas.Set(left, MinimapHeight/2, 'E', cardinalStyle)
+	canvas.Set(left+MinimapWidth-1, MinimapHeight/2, 'W', cardinalStyle)
+}
+
+// minimapPoint maps a direction onto the dome with an equidistant
+// projection: the zenith at the center and the horizon at minimapRadius.
+// Directions below the horizon are pinned to the horizon circle.
+func minimapPoint(v vec3) (x, y int) {
+	r := minimapRadius * math.Acos(math.Max(-1, math.Min(1, v.z))) / (math.Pi / 2)
+	r = math.Min(r, minimapRadius)
+
+	planar := math.Hypot(v.x, v.y)
+	if planar < 1e-9 {
+		return minimapDot(0, 0)
+	}
+
+	// East (x) is drawn to the left, north (y) up
+	return minimapDot(-r*v.x/planar, r*v.y/planar)
+}
+
+// minimapDot converts dome offsets (right, up) from the zenith into Braille
+// dot coordinates
+func minimapDot(right, up float64) (x, y int) {
+	cx := float64(MinimapWidth)      // 2 dots per cell, centered
+	cy := float64(MinimapHeight * 2) // 4 dots per cell, centered
+	return int(math.Round(cx + right)), int(math.Round(cy - up))
+}
@@ -0,0 +1,67 @@
+package render
+
+import "testing"
+
+func TestMinimapPointOrientation(t *testing.T) {
+	zx, zy := minimapPoint(horizontalVector(90, 0))
+	if zx != MinimapWidth || zy != MinimapHeight*2 {
+		t.Errorf("zenith at (%d, %d), want the dome center", zx, zy)
+	}
+
+	// North is up and east is on the left, as seen looking up
+	nx, ny := minimapPoint(horizontalVector(0, 0))
+	ex, ey := minimapPoint(horizontalVector(0, 90))
+	if nx != zx || ny != zy-int(minimapRadius) {
+		t.Errorf("north horizon at (%d, %d)", nx, ny)
+	}
+	if ey != zy || ex != zx-int(minimapRadius) {
+		t.Errorf("east horizon at (%d, %d)", ex, ey)
+	}
+
+	// Below the horizon is pinned to the horizon circle
+	bx, by := minimapPoint(horizontalVector(-30, 0))
+	if bx != nx || by != ny {
+		t.Errorf("below-horizon point at (%d, %d), want (%d, %d)", bx, by, nx, ny)
+	}
+}
+
+func TestRenderMinimapInset(t *testing.T) {
+	canvas := NewCanvas(80, 30)
+
+	RenderMinimap(canvas, nil, 0, 0, false, 45, 180, 60)
+
+	left := canvas.Width - MinimapWidth
+	if canvas.Cells[0][left+MinimapWidth/2].Char != 'N' {
+		t.Errorf("missing north marker: %q", rowText(canvas, 0))
+	}
+
+	// The view toward the south sits in the lower half of the dome
+	drawn := false
+	for y := MinimapHeight / 2; y < MinimapHeight; y++ {
+		for x := left; x < canvas.Width; x++ {
+			if c := canvas.Cells[y][x].Char; c > brailleBase && c <= brailleBase+0xFF {
+				drawn = true
+			}
+		}
+	}
+	if !drawn {
+		t.Error("no dots drawn in the southern half of the dome")
+	}
+
+	// Nothing is drawn outside the inset
+	if canvas.Cells[0][left-1].Char != ' ' || canvas.Cells[MinimapHeight][left].Char != ' ' {
+		t.Error("minimap drew outside its corner")
+	}
+}
+
+func TestRenderMinimapSkipsSmallCanvas(t *testing.T) {
+	canvas := NewCanvas(MinimapWidth, MinimapHeight)
+
+	RenderMinimap(canvas, nil, 0, 0, false, 45, 180, 60)
+
+	for y := range canvas.Cells {
+		if row := rowText(canvas, y); row != "                    " {
+			t.Fatalf("small canvas was drawn on: %q", row)
+		}
+	}
+}
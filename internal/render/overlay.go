@@ -0,0 +1,52 @@
+package render
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/optics"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// overlaySegments is the number of chords used to draw an eyepiece circle
+const overlaySegments = 48
+
+// RenderFieldOfView outlines the true field of an equipment setup centered
+// on targetAlt/targetAz. Eyepieces and finders draw a circle, cameras a
+// rectangle rotated by the setup's rotation (clockwise from vertical).
+func RenderFieldOfView(canvas *Canvas, setup optics.Setup, targetAlt, targetAz, centerAlt, centerAz, fov float64) {
+	basis := newViewBasis(centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+	style := lipgloss.NewStyle().Foreground(theme.Current().Overlay)
+	target := horizontalVector(targetAlt, targetAz)
+
+	var outline []vec3
+	if setup.Circular {
+		outline = ellipseOutline(target, setup.Width/2, setup.Width/2, 0, overlaySegments)
+	} else {
+		right, up := tangentFrame(target)
+
+		// Corners are offset in the tangent plane at the target
+		w := math.Tan(setup.Width / 2 * math.Pi / 180.0)
+		h := math.Tan(setup.Height / 2 * math.Pi / 180.0)
+		sin, cos := math.Sincos(setup.Rotation * math.Pi / 180.0)
+
+		for _, c := range [][2]float64{{-w, h}, {w, h}, {w, -h}, {-w, -h}, {-w, h}} {
+			x := c[0]*cos + c[1]*sin
+			y := -c[0]*sin + c[1]*cos
+			outline = append(outline, target.add(right.scale(x)).add(up.scale(y)).normalize())
+		}
+	}
+
+	drawOutline(canvas, basis, outline, fov, style)
+
+	// A field smaller than a cell would vanish, so mark its center instead
+	x, y, front := basis.project(target)
+	if front && x >= 0 && y >= 0 && int(x) < canvas.Width && int(y) < canvas.Height {
+		if setup.Width*basis.cellsPerDegree() < 2 {
+			cell := canvas.Cells[int(y)][int(x)]
+			if cell.Char == ' ' || cell.Background {
+				canvas.SetBackground(int(x), int(y), '+', style)
+			}
+		}
+	}
+}
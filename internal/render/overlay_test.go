@@ -0,0 +1,43 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/craigderington/skyterm/internal/optics"
+)
+
+func TestRenderFieldOfViewCircle(t *testing.T) {
+	canvas := NewCanvas(80, 40)
+	setup := optics.Setup{Circular: true, Width: 20, Height: 20}
+
+	RenderFieldOfView(canvas, setup, 45, 180, 45, 180, 60)
+
+	// The outline crosses the horizontal line through the center on both sides
+	row := rowText(canvas, 20)
+	left, right := -1, -1
+	for x, r := range []rune(row) {
+		if r != ' ' {
+			if left < 0 {
+				left = x
+			}
+			right = x
+		}
+	}
+	if left < 0 || left >= 40 || right <= 40 {
+		t.Fatalf("circle does not surround the center: %q", row)
+	}
+	if canvas.Cells[20][40].Char != ' ' {
+		t.Error("circle center should be left empty")
+	}
+}
+
+func TestRenderFieldOfViewTinyFieldMarksCenter(t *testing.T) {
+	canvas := NewCanvas(80, 40)
+	setup := optics.Setup{Circular: true, Width: 0.1, Height: 0.1}
+
+	RenderFieldOfView(canvas, setup, 45, 180, 45, 180, 60)
+
+	if canvas.Cells[20][40].Char != '+' {
+		t.Errorf("center = %q, want '+'", canvas.Cells[20][40].Char)
+	}
+}
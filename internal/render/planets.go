@@ -1,36 +1,40 @@
 package render
 
 import (
+	"math"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
+// Planet colors and symbols
+var planetStyles = map[string]struct {
+	char  rune
+	color lipgloss.Color
+}{
+	"Sun":     {'☉', lipgloss.Color("226")}, // Bright yellow
+	"Moon":    {'☽', lipgloss.Color("250")}, // Light gray
+	"Mercury": {'☿', lipgloss.Color("249")}, // Gray
+	"Venus":   {'♀', lipgloss.Color("230")}, // Yellowish-white
+	"Mars":    {'♂', lipgloss.Color("196")}, // Red
+	"Jupiter": {'♃', lipgloss.Color("215")}, // Orange-white
+	"Saturn":  {'♄', lipgloss.Color("229")}, // Pale yellow
+	"Uranus":  {'♅', lipgloss.Color("117")}, // Pale cyan
+	"Neptune": {'♆', lipgloss.Color("27")},  // Blue
+}
+
 // RenderPlanets draws planets on the canvas
-func RenderPlanets(canvas *Canvas, planets *astro.PlanetarySystem, centerAlt, centerAz, fov float64) {
+func RenderPlanets(canvas *Canvas, planets *astro.PlanetarySystem, centerAlt, centerAz, fov float64, vis Visibility) {
 	if planets == nil {
 		return
 	}
 
-	// Planet colors and symbols
-	planetStyles := map[string]struct {
-		char  rune
-		color lipgloss.Color
-	}{
-		"Sun":     {'☉', lipgloss.Color("226")}, // Bright yellow
-		"Moon":    {'☽', lipgloss.Color("250")}, // Light gray
-		"Mercury": {'☿', lipgloss.Color("249")}, // Gray
-		"Venus":   {'♀', lipgloss.Color("230")}, // Yellowish-white
-		"Mars":    {'♂', lipgloss.Color("196")}, // Red
-		"Jupiter": {'♃', lipgloss.Color("215")}, // Orange-white
-		"Saturn":  {'♄', lipgloss.Color("229")}, // Pale yellow
-		"Uranus":  {'♅', lipgloss.Color("117")}, // Pale cyan
-		"Neptune": {'♆', lipgloss.Color("27")},  // Blue
-	}
+	basis := newViewBasis(centerAlt, centerAz, fov, canvas.Width, canvas.Height)
 
 	for _, planet := range planets.AllPlanets() {
-		// Project planet to screen coordinates
-		x, y, visible := Project(planet.Altitude, planet.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
-		if !visible {
+		// Skip planets washed out by daylight or moonlight
+		if !vis.PlanetVisible(planet) {
 			continue
 		}
 
@@ -41,45 +45,95 @@ func RenderPlanets(canvas *Canvas, planets *astro.PlanetarySystem, centerAlt, ce
 
 		// Render planet symbol with bold
 		planetStyle := lipgloss.NewStyle().
-			Foreground(style.color).
+			Foreground(theme.Current().Color(style.color)).
 			Bold(true)
 
+		// Zoomed in far enough, the Sun and Moon are drawn at true size
+		if drawDisc(canvas, basis, planet, planetStyle) {
+			continue
+		}
+
+		// Project planet to screen coordinates
+		x, y, visible := Project(planet.Altitude, planet.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+		if !visible {
+			continue
+		}
+
 		canvas.Set(x, y, style.char, planetStyle)
 	}
 }
 
-// RenderPlanetLabels draws planet name labels
-func RenderPlanetLabels(canvas *Canvas, planets *astro.PlanetarySystem, centerAlt, centerAz, fov float64) {
+// minDiscCells is the projected diameter at which a body is drawn as a
+// filled disc instead of a symbol
+const minDiscCells = 3.0
+
+// drawDisc fills the cells covered by a body's apparent disc. It reports
+// false when the body is too small on screen to be drawn as a disc.
+func drawDisc(canvas *Canvas, basis viewBasis, planet astro.Planet, style lipgloss.Style) bool {
+	if planet.AngularDiameter <= 0 {
+		return false
+	}
+
+	// Screen radii; cells are taller than wide, so they differ per axis
+	r := math.Sin(planet.AngularDiameter / 2 * math.Pi / 180.0)
+	rx := r * basis.scaleX
+	ry := r * basis.scaleY
+	if 2*rx < minDiscCells {
+		return false
+	}
+
+	cx, cy, front := basis.project(horizontalVector(planet.Altitude, planet.Azimuth))
+	if !front {
+		return true
+	}
+
+	minY := int(math.Max(0, math.Floor(cy-ry)))
+	maxY := int(math.Min(float64(canvas.Height-1), math.Ceil(cy+ry)))
+	minX := int(math.Max(0, math.Floor(cx-rx)))
+	maxX := int(math.Min(float64(canvas.Width-1), math.Ceil(cx+rx)))
+
+	for y := minY; y <= maxY; y++ {
+		dy := (float64(y) + 0.5 - cy) / ry
+		for x := minX; x <= maxX; x++ {
+			dx := (float64(x) + 0.5 - cx) / rx
+			if dx*dx+dy*dy <= 1 {
+				canvas.Set(x, y, '█', style)
+			}
+		}
+	}
+
+	return true
+}
+
+// RenderPlanetLabels queues planet name labels
+func RenderPlanetLabels(canvas *Canvas, labels *LabelLayout, planets *astro.PlanetarySystem, centerAlt, centerAz, fov float64, vis Visibility) {
 	if planets == nil {
 		return
 	}
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("yellow")).
+		Foreground(theme.Current().PlanetLabel).
 		Bold(true)
 
 	for _, planet := range planets.AllPlanets() {
-		// Project planet to screen coordinates
-		x, y, visible := Project(planet.Altitude, planet.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
-		if !visible {
+		// Skip planets washed out by daylight or moonlight
+		if !vis.PlanetVisible(planet) {
 			continue
 		}
 
-		// Position label to the right of the planet
-		labelX := x + 2
-		labelY := y
-
-		// Check bounds
-		if labelY < 0 || labelY >= canvas.Height {
+		// Project planet to screen coordinates
+		x, y, visible := Project(planet.Altitude, planet.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+		if !visible {
 			continue
 		}
 
-		// Draw label
-		for i, ch := range planet.Name {
-			xPos := labelX + i
-			if xPos >= 0 && xPos < canvas.Width {
-				canvas.Set(xPos, labelY, ch, labelStyle)
-			}
-		}
+		labels.Add(Label{
+			Text:     planet.Name,
+			X:        x,
+			Y:        y,
+			Priority: PriorityPlanet,
+			Rank:     planet.Magnitude,
+			Style:    labelStyle,
+		})
 	}
 }
@@ -0,0 +1,203 @@
+package render
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// vec3 is a direction on the unit sphere in horizontal coordinates
+// (x = east, y = north, z = zenith)
+type vec3 struct {
+	x, y, z float64
+}
+
+// horizontalVector converts altitude/azimuth in degrees to a unit vector
+func horizontalVector(alt, az float64) vec3 {
+	altRad := alt * math.Pi / 180.0
+	azRad := az * math.Pi / 180.0
+
+	return vec3{
+		x: math.Cos(altRad) * math.Sin(azRad),
+		y: math.Cos(altRad) * math.Cos(azRad),
+		z: math.Sin(altRad),
+	}
+}
+
+func (v vec3) dot(o vec3) float64 {
+	return v.x*o.x + v.y*o.y + v.z*o.z
+}
+
+func (v vec3) cross(o vec3) vec3 {
+	return vec3{
+		x: v.y*o.z - v.z*o.y,
+		y: v.z*o.x - v.x*o.z,
+		z: v.x*o.y - v.y*o.x,
+	}
+}
+
+func (v vec3) scale(f float64) vec3 {
+	return vec3{v.x * f, v.y * f, v.z * f}
+}
+
+func (v vec3) add(o vec3) vec3 {
+	return vec3{v.x + o.x, v.y + o.y, v.z + o.z}
+}
+
+func (v vec3) normalize() vec3 {
+	l := math.Sqrt(v.dot(v))
+	if l == 0 {
+		return v
+	}
+	return v.scale(1 / l)
+}
+
+// cellAspect is the height of a terminal cell relative to its width
+const cellAspect = 2.0
+
+// viewBasis holds the orthonormal frame of the current view
+type viewBasis struct {
+	center, right, up vec3
+	scaleX, scaleY    float64 // Cells per unit of projected offset
+	width, height     float64
+}
+
+// newViewBasis builds the projection frame for a view centered on
+// centerAlt/centerAz. The field of view spans the canvas width, and rows
+// use the same angular scale corrected for the cell aspect so circles stay
+// round at any zoom.
+func newViewBasis(centerAlt, centerAz, fov float64, screenWidth, screenHeight int) viewBasis {
+	center := horizontalVector(centerAlt, centerAz)
+
+	// Right vector (cross product of center and celestial up)
+	right := center.cross(vec3{0, 0, 1})
+	if right.dot(right) < 1e-12 {
+		// Straight up or down: keep the orientation of a view tilted toward centerAz
+		azRad := centerAz * math.Pi / 180.0
+		right = vec3{math.Cos(azRad), -math.Sin(azRad), 0}
+	}
+	right = right.normalize()
+
+	// Recalculate up vector (cross product of right and center)
+	up := right.cross(center)
+
+	fovRad := fov * math.Pi / 180.0
+	scale := float64(screenWidth) / 2.0 / math.Sin(math.Min(fovRad, math.Pi)/2.0)
+
+	return viewBasis{
+		center: center,
+		right:  right,
+		up:     up,
+		scaleX: scale,
+		scaleY: scale / cellAspect,
+		width:  float64(screenWidth),
+		height: float64(screenHeight),
+	}
+}
+
+// project returns unclipped screen coordinates for a direction. front is
+// false for directions behind the viewer, where the projection is undefined.
+func (b viewBasis) project(v vec3) (x, y float64, front bool) {
+	x = b.width/2.0 + v.dot(b.right)*b.scaleX
+	y = b.height/2.0 - v.dot(b.up)*b.scaleY
+
+	return x, y, v.dot(b.center) > 0
+}
+
+// unproject returns the direction shown at screen coordinates x, y. Points
+// past the edge of the visible hemisphere are pulled back onto its rim.
+func (b viewBasis) unproject(x, y float64) vec3 {
+	a := (x - b.width/2.0) / b.scaleX
+	c := (b.height/2.0 - y) / b.scaleY
+	if r := a*a + c*c; r > 1 {
+		r = math.Sqrt(r)
+		a, c = a/r, c/r
+	}
+
+	depth := math.Sqrt(math.Max(0, 1-a*a-c*c))
+	return b.center.scale(depth).add(b.right.scale(a)).add(b.up.scale(c))
+}
+
+// cellsPerDegree returns the horizontal screen scale near the view center
+func (b viewBasis) cellsPerDegree() float64 {
+	return b.scaleX * math.Pi / 180.0
+}
+
+// Project converts horizontal coordinates to screen cells for a view
+// centered on centerAlt/centerAz whose field of view spans the screen width
+func Project(alt, az, centerAlt, centerAz, fov float64, screenWidth, screenHeight int) (x, y int, visible bool) {
+	basis := newViewBasis(centerAlt, centerAz, fov, screenWidth, screenHeight)
+
+	fx, fy, front := basis.project(horizontalVector(alt, az))
+	if !front || fx < 0 || fy < 0 {
+		return 0, 0, false
+	}
+
+	x = int(fx)
+	y = int(fy)
+
+	// Check bounds
+	if x >= screenWidth || y >= screenHeight {
+		return 0, 0, false
+	}
+
+	return x, y, true
+}
+
+// Unproject converts screen coordinates back to horizontal coordinates for
+// a view centered on centerAlt/centerAz. It inverts Project; points beyond
+// the visible hemisphere map to its rim.
+func Unproject(x, y, centerAlt, centerAz, fov float64, screenWidth, screenHeight int) (alt, az float64) {
+	basis := newViewBasis(centerAlt, centerAz, fov, screenWidth, screenHeight)
+	return horizontalAngles(basis.unproject(x, y))
+}
+
+// horizontalAngles converts a unit vector back to altitude/azimuth in degrees
+func horizontalAngles(v vec3) (alt, az float64) {
+	alt = math.Asin(math.Max(-1, math.Min(1, v.z))) * 180.0 / math.Pi
+	az = math.Atan2(v.x, v.y) * 180.0 / math.Pi
+	if az < 0 {
+		az += 360
+	}
+	return alt, az
+}
+
+// tangentFrame returns unit vectors toward screen right and screen up at a
+// direction, matching the orientation of a view centered there
+func tangentFrame(target vec3) (right, up vec3) {
+	right = target.cross(vec3{0, 0, 1})
+	if right.dot(right) < 1e-12 {
+		right = vec3{1, 0, 0} // Target at the zenith
+	}
+	right = right.normalize()
+	return right, right.cross(target)
+}
+
+// ellipseOutline returns points around an ellipse centered on target. The
+// semi-axes are in degrees and angle turns the major axis clockwise from
+// screen up. Offsets are gnomonic, so small ellipses keep their true shape.
+func ellipseOutline(target vec3, semiMajor, semiMinor, angle float64, segments int) []vec3 {
+	right, up := tangentFrame(target)
+
+	a := math.Tan(semiMajor * math.Pi / 180.0)
+	b := math.Tan(semiMinor * math.Pi / 180.0)
+	sin, cos := math.Sincos(angle * math.Pi / 180.0)
+	major := right.scale(sin).add(up.scale(cos))
+	minor := right.scale(cos).add(up.scale(-sin))
+
+	points := make([]vec3, 0, segments+1)
+	for i := 0; i <= segments; i++ {
+		phi := 2 * math.Pi * float64(i) / float64(segments)
+		offset := major.scale(a * math.Cos(phi)).add(minor.scale(b * math.Sin(phi)))
+		points = append(points, target.add(offset).normalize())
+	}
+	return points
+}
+
+// drawOutline connects consecutive points with great-circle arcs
+func drawOutline(canvas *Canvas, basis viewBasis, points []vec3, fov float64, style lipgloss.Style) {
+	maxStep := math.Min(2.0, fov/30.0) * math.Pi / 180.0
+	for i := 1; i < len(points); i++ {
+		drawArc(canvas, basis, points[i-1], points[i], maxStep, style)
+	}
+}
@@ -0,0 +1,63 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/craigderington/skyterm/internal/astro"
+)
+
+func TestProjectFieldSpansWidth(t *testing.T) {
+	// Points half a field of view left and right land on the screen edges
+	x, _, visible := Project(0, 179.9, 0, 150, 60, 120, 30)
+	if !visible || x != 119 {
+		t.Errorf("right edge projected to x=%d (visible=%v), want 119", x, visible)
+	}
+	x, _, visible = Project(0, 120.1, 0, 150, 60, 120, 30)
+	if !visible || x != 0 {
+		t.Errorf("left edge projected to x=%d (visible=%v), want 0", x, visible)
+	}
+}
+
+func TestProjectKeepsAspect(t *testing.T) {
+	// One degree up moves half as many rows as one degree right moves columns
+	basis := newViewBasis(0, 180, 2, 120, 60)
+	x, _, _ := basis.project(horizontalVector(0, 181)) // West is right when facing south
+	_, y, _ := basis.project(horizontalVector(1, 180))
+
+	dx := x - 60
+	dy := 30 - y
+	if dx < 59 || dx > 61 || dy < 29 || dy > 31 {
+		t.Errorf("1° offsets = %.1f columns, %.1f rows; want 60 and 30", dx, dy)
+	}
+}
+
+func TestProjectZenithView(t *testing.T) {
+	// Looking straight up still spreads the sky across the screen
+	x1, _, ok1 := Project(80, 0, 90, 180, 60, 120, 30)
+	x2, _, ok2 := Project(80, 90, 90, 180, 60, 120, 30)
+	if !ok1 || !ok2 || x1 == x2 {
+		t.Errorf("zenith view collapsed: x=%d (%v), x=%d (%v)", x1, ok1, x2, ok2)
+	}
+}
+
+func TestUnprojectInvertsProject(t *testing.T) {
+	for _, tc := range []struct{ alt, az, centerAlt, centerAz, fov float64 }{
+		{50, 200, 45, 180, 60},
+		{10, 355, 5, 10, 40},
+		{88, 90, 90, 0, 90},
+		{30.05, 120.02, 30, 120, 0.5},
+	} {
+		x, y, visible := Project(tc.alt, tc.az, tc.centerAlt, tc.centerAz, tc.fov, 120, 30)
+		if !visible {
+			t.Fatalf("%+v: not visible", tc)
+		}
+
+		alt, az := Unproject(float64(x)+0.5, float64(y)+0.5, tc.centerAlt, tc.centerAz, tc.fov, 120, 30)
+
+		// Within one cell of the original position
+		tolerance := tc.fov / 120 * 2
+		if d := astro.AngularSeparation(alt, az, tc.alt, tc.az); d > tolerance {
+			t.Errorf("%+v: unprojected to %.3f/%.3f, %.3f° away", tc, alt, az, d)
+		}
+	}
+}
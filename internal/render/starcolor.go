@@ -0,0 +1,88 @@
+package render
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/catalog"
+)
+
+// Effective temperatures in Kelvin for stars without a B-V index
+var spectralTemperatures = map[rune]float64{
+	'O': 35000,
+	'B': 18000,
+	'A': 9000,
+	'F': 6800,
+	'G': 5700,
+	'K': 4500,
+	'M': 3300,
+}
+
+// Brightness range used to scale star colors by magnitude
+const (
+	brightestMagnitude = -1.0 // Full intensity at or above this brightness
+	faintestMagnitude  = 6.0  // Dimmest rendered intensity
+	minimumIntensity   = 0.35
+)
+
+// StarColor returns a truecolor value for a star from its B-V index, or from
+// its spectral type when B-V is unknown, dimmed according to magnitude.
+// lipgloss downsamples the color to the nearest 256- or 16-color palette
+// entry when the terminal does not support truecolor.
+func StarColor(star catalog.Star) lipgloss.Color {
+	temperature, ok := spectralTemperatures[star.SpectralType]
+	if star.HasColorIndex {
+		temperature = colorIndexTemperature(star.ColorIndex)
+	} else if !ok {
+		temperature = spectralTemperatures['A']
+	}
+
+	r, g, b := temperatureRGB(temperature)
+	intensity := magnitudeIntensity(star.Magnitude)
+
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x",
+		uint8(r*intensity), uint8(g*intensity), uint8(b*intensity)))
+}
+
+// colorIndexTemperature converts a B-V color index to an effective
+// temperature using Ballesteros' formula
+func colorIndexTemperature(bv float64) float64 {
+	return 4600.0 * (1.0/(0.92*bv+1.7) + 1.0/(0.92*bv+0.62))
+}
+
+// temperatureRGB approximates the sRGB color of a blackbody at the given
+// temperature, with each channel in the range 0-255
+func temperatureRGB(kelvin float64) (r, g, b float64) {
+	t := math.Max(1000, math.Min(40000, kelvin)) / 100.0
+
+	if t <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(t) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(t-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(t-60, -0.0755148492)
+	}
+
+	switch {
+	case t >= 66:
+		b = 255
+	case t <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(t-10) - 305.0447927307
+	}
+
+	clamp := func(v float64) float64 {
+		return math.Max(0, math.Min(255, v))
+	}
+	return clamp(r), clamp(g), clamp(b)
+}
+
+// magnitudeIntensity maps magnitude to a brightness factor between
+// minimumIntensity and 1
+func magnitudeIntensity(magnitude float64) float64 {
+	f := (faintestMagnitude - magnitude) / (faintestMagnitude - brightestMagnitude)
+	f = math.Max(0, math.Min(1, f))
+	return minimumIntensity + (1-minimumIntensity)*f
+}
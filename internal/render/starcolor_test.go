@@ -0,0 +1,59 @@
+package render
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/craigderington/skyterm/internal/catalog"
+)
+
+func parseHex(t *testing.T, color string) (r, g, b int64) {
+	t.Helper()
+	if len(color) != 7 || color[0] != '#' {
+		t.Fatalf("expected #rrggbb, got %q", color)
+	}
+	r, _ = strconv.ParseInt(color[1:3], 16, 0)
+	g, _ = strconv.ParseInt(color[3:5], 16, 0)
+	b, _ = strconv.ParseInt(color[5:7], 16, 0)
+	return r, g, b
+}
+
+func TestStarColorFromColorIndex(t *testing.T) {
+	rigel := catalog.Star{Magnitude: 0.1, ColorIndex: -0.03, HasColorIndex: true}
+	betelgeuse := catalog.Star{Magnitude: 0.1, ColorIndex: 1.85, HasColorIndex: true}
+
+	r, _, b := parseHex(t, string(StarColor(rigel)))
+	if b <= r {
+		t.Errorf("hot star should be bluer than red: %s", StarColor(rigel))
+	}
+
+	r, _, b = parseHex(t, string(StarColor(betelgeuse)))
+	if r <= b {
+		t.Errorf("cool star should be redder than blue: %s", StarColor(betelgeuse))
+	}
+}
+
+func TestStarColorFallsBackToSpectralType(t *testing.T) {
+	withIndex := catalog.Star{Magnitude: 1, SpectralType: 'M', ColorIndex: -0.3, HasColorIndex: true}
+	withoutIndex := catalog.Star{Magnitude: 1, SpectralType: 'M'}
+
+	if StarColor(withIndex) == StarColor(withoutIndex) {
+		t.Error("B-V index should take precedence over spectral type")
+	}
+
+	r, _, b := parseHex(t, string(StarColor(withoutIndex)))
+	if r <= b {
+		t.Errorf("M star without B-V should be red, got %s", StarColor(withoutIndex))
+	}
+}
+
+func TestStarColorDimsWithMagnitude(t *testing.T) {
+	bright := catalog.Star{Magnitude: -1, ColorIndex: 0.6, HasColorIndex: true}
+	faint := catalog.Star{Magnitude: 5.5, ColorIndex: 0.6, HasColorIndex: true}
+
+	br, bg, bb := parseHex(t, string(StarColor(bright)))
+	fr, fg, fb := parseHex(t, string(StarColor(faint)))
+	if fr+fg+fb >= br+bg+bb {
+		t.Errorf("faint star %s should be darker than bright star %s", StarColor(faint), StarColor(bright))
+	}
+}
@@ -3,12 +3,13 @@ package render
 import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/craigderington/skyterm/internal/catalog"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
-// RenderStarLabels renders labels for bright stars
-func RenderStarLabels(canvas *Canvas, stars []catalog.Star, centerAlt, centerAz, fov, magLimit float64) {
+// RenderStarLabels queues labels for bright stars
+func RenderStarLabels(canvas *Canvas, labels *LabelLayout, stars []catalog.Star, centerAlt, centerAz, fov float64, vis Visibility) {
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("white")).
+		Foreground(theme.Current().StarLabel).
 		Faint(true)
 
 	for _, star := range stars {
@@ -18,7 +19,7 @@ func RenderStarLabels(canvas *Canvas, stars []catalog.Star, centerAlt, centerAz,
 		}
 
 		// Skip if star itself is not visible
-		if star.Magnitude > magLimit {
+		if !vis.Visible(star.Magnitude, star.Altitude, star.Azimuth) {
 			continue
 		}
 
@@ -28,21 +29,13 @@ func RenderStarLabels(canvas *Canvas, stars []catalog.Star, centerAlt, centerAz,
 			continue
 		}
 
-		// Position label to the right and slightly up
-		labelX := x + 2
-		labelY := y
-
-		// Check bounds
-		if labelY < 0 || labelY >= canvas.Height {
-			continue
-		}
-
-		// Draw label
-		for i, ch := range star.Name {
-			xPos := labelX + i
-			if xPos >= 0 && xPos < canvas.Width {
-				canvas.Set(xPos, labelY, ch, labelStyle)
-			}
-		}
+		labels.Add(Label{
+			Text:     star.Name,
+			X:        x,
+			Y:        y,
+			Priority: PriorityStar,
+			Rank:     vis.ApparentMagnitude(star.Magnitude, star.Altitude),
+			Style:    labelStyle,
+		})
 	}
 }
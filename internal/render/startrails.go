@@ -0,0 +1,94 @@
+package render
+
+import (
+	"math"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/catalog"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// maxTrailSamples caps the positions computed per star in one Accumulate
+// call, so a huge time jump can't stall a frame
+const maxTrailSamples = 720
+
+// siderealDegree is the time the sky takes to turn one degree
+const siderealDegree = 239 * time.Second
+
+// StarTrails accumulates star paths across simulated time, like a long
+// exposure photograph taken from the observer's site. The buffer is tied to
+// one view and starts over when the view changes.
+type StarTrails struct {
+	buffer       *Canvas
+	alt, az, fov float64
+	Exposure     time.Duration // Simulated time accumulated so far
+}
+
+// NewStarTrails creates an empty accumulation buffer
+func NewStarTrails() *StarTrails {
+	return &StarTrails{}
+}
+
+// Reset discards the accumulated trails
+func (st *StarTrails) Reset() {
+	st.buffer = nil
+	st.Exposure = 0
+}
+
+// Accumulate adds each star's path between from and to. Paths are sampled
+// at least once per degree of sky rotation, finer at narrow fields. A
+// different view or canvas size starts a new exposure, even when no time
+// has passed.
+func (st *StarTrails) Accumulate(width, height int, stars []catalog.Star, observer *astro.Observer, from, to time.Time, centerAlt, centerAz, fov float64, vis Visibility) {
+	if st.buffer == nil || st.buffer.Width != width || st.buffer.Height != height ||
+		st.alt != centerAlt || st.az != centerAz || st.fov != fov {
+		st.buffer = NewCanvas(width, height)
+		st.alt, st.az, st.fov = centerAlt, centerAz, fov
+		st.Exposure = 0
+	}
+
+	span := to.Sub(from)
+	if span == 0 {
+		return
+	}
+	st.Exposure += span.Abs()
+
+	step := time.Duration(float64(siderealDegree) * math.Min(1.0, fov/60.0))
+	samples := int(math.Ceil(float64(span.Abs()) / float64(step)))
+	samples = max(1, min(samples, maxTrailSamples))
+
+	basis := newViewBasis(centerAlt, centerAz, fov, width, height)
+	maxStep := math.Min(2.0, fov/30.0) * math.Pi / 180.0
+	th := theme.Current()
+
+	for _, star := range stars {
+		style := lipgloss.NewStyle().Foreground(th.Color(StarColor(star))).Faint(star.Magnitude > 2)
+		eq := astro.EquatorialCoords{RA: star.RA, Dec: star.Dec}
+
+		prev := astro.EquatorialToHorizontal(eq, observer, from)
+		for i := 1; i <= samples; i++ {
+			t := from.Add(time.Duration(float64(span) * float64(i) / float64(samples)))
+			cur := astro.EquatorialToHorizontal(eq, observer, t)
+
+			if vis.Visible(star.Magnitude, prev.Altitude, prev.Azimuth) && vis.Visible(star.Magnitude, cur.Altitude, cur.Azimuth) {
+				drawArc(st.buffer, basis,
+					horizontalVector(prev.Altitude, prev.Azimuth),
+					horizontalVector(cur.Altitude, cur.Azimuth),
+					maxStep, style)
+			}
+			prev = cur
+		}
+	}
+}
+
+// Draw copies the accumulated trails onto the canvas beneath its stars.
+// Trails from a different view are skipped; the next Accumulate discards
+// them.
+func (st *StarTrails) Draw(canvas *Canvas, centerAlt, centerAz, fov float64) {
+	if st.buffer == nil || st.alt != centerAlt || st.az != centerAz || st.fov != fov {
+		return
+	}
+	canvas.DrawLayer(st.buffer)
+}
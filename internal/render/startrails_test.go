@@ -0,0 +1,54 @@
+package render
+
+import (
+	"testing"
+	"time"
+
+	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/catalog"
+)
+
+func TestStarTrailsAccumulateAndReset(t *testing.T) {
+	observer := astro.NewObserver(45, 0, 0, "Test")
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+	stars := []catalog.Star{{Name: "Kochab", Magnitude: 2.1, RA: 14.85, Dec: 74.16}}
+	vis := Visibility{Limit: 6}
+
+	trails := NewStarTrails()
+	trails.Accumulate(80, 30, stars, observer, from, to, 45, 0, 60, vis)
+	if trails.Exposure != 2*time.Hour {
+		t.Errorf("exposure = %v, want 2h", trails.Exposure)
+	}
+
+	// Two hours turn the sky 30°, about 10 columns at this distance from the pole
+	canvas := NewCanvas(80, 30)
+	trails.Draw(canvas, 45, 0, 60)
+	drawn := 0
+	for y := 0; y < canvas.Height; y++ {
+		for _, cell := range canvas.Cells[y] {
+			if cell.Char != ' ' {
+				drawn++
+			}
+		}
+	}
+	if drawn < 8 {
+		t.Errorf("trail covers %d cells, want a visible arc", drawn)
+	}
+
+	// Drawing a panned view leaves the trails alone
+	panned := NewCanvas(80, 30)
+	trails.Draw(panned, 45, 10, 60)
+	if panned.PlainText() != NewCanvas(80, 30).PlainText() {
+		t.Error("trails from another view were drawn")
+	}
+	if trails.Exposure != 2*time.Hour {
+		t.Error("Draw should not change the exposure")
+	}
+
+	// The next accumulation discards the exposure, even with no time passed
+	trails.Accumulate(80, 30, stars, observer, to, to, 45, 10, 60, vis)
+	if trails.Exposure != 0 {
+		t.Error("view change should reset the exposure")
+	}
+}
@@ -1,22 +1,26 @@
 package render
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/catalog"
+	"github.com/craigderington/skyterm/internal/theme"
+)
 
-// GetStarStyle returns an enhanced style for a star based on its magnitude and spectral type
-func GetStarStyle(magnitude float64, spectralType rune, colorByType bool) lipgloss.Style {
+// GetStarStyle returns an enhanced style for a star based on its color index and magnitude
+func GetStarStyle(star catalog.Star, colorByType bool) lipgloss.Style {
 	style := lipgloss.NewStyle()
+	th := theme.Current()
 
 	if colorByType {
-		// Color by spectral type
-		color := getColorForSpectralType(spectralType)
-		style = style.Foreground(color)
+		// Color from B-V index or spectral type, scaled by brightness
+		style = style.Foreground(th.Color(StarColor(star)))
 	} else {
 		// Default white
-		style = style.Foreground(lipgloss.Color("231"))
+		style = style.Foreground(th.Color("231"))
 	}
 
 	// Brightest stars get bold
-	if magnitude < 0.5 {
+	if star.Magnitude < 0.5 {
 		style = style.Bold(true)
 	}
 
@@ -0,0 +1,32 @@
+package render
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// RenderTooltip draws text beside the cell at x, y, above and to the right
+// when there is room, and flipped to stay within the canvas otherwise
+func RenderTooltip(canvas *Canvas, x, y int, text string) {
+	th := theme.Current()
+	style := lipgloss.NewStyle().
+		Foreground(th.StatusText).
+		Background(th.StatusBackground)
+
+	runes := []rune(" " + text + " ")
+
+	left := x + 2
+	if left+len(runes) > canvas.Width {
+		left = x - 1 - len(runes)
+	}
+	left = max(0, left)
+
+	row := y - 1
+	if row < 0 {
+		row = y + 1
+	}
+
+	for i, r := range runes {
+		canvas.Set(left+i, row, r, style)
+	}
+}
@@ -0,0 +1,30 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTooltipAboveRight(t *testing.T) {
+	canvas := NewCanvas(40, 10)
+
+	RenderTooltip(canvas, 5, 5, "Vega")
+
+	if row := rowText(canvas, 4); !strings.HasPrefix(row[7:], " Vega ") {
+		t.Errorf("tooltip not above and right of the pointer: %q", row)
+	}
+}
+
+func TestRenderTooltipStaysOnCanvas(t *testing.T) {
+	canvas := NewCanvas(40, 10)
+
+	RenderTooltip(canvas, 38, 0, "Arcturus")
+
+	row := rowText(canvas, 1)
+	if !strings.Contains(row, " Arcturus ") {
+		t.Fatalf("tooltip missing below the top edge: %q", row)
+	}
+	if strings.Index(row, "Arcturus") > 38 {
+		t.Errorf("tooltip should flip to the left of the pointer: %q", row)
+	}
+}
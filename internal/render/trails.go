@@ -0,0 +1,89 @@
+package render
+
+import (
+	"math"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// Screen distances kept between trail ticks and between date labels, so
+// dense samples still leave the path itself visible
+const (
+	minTickCells      = 3.0
+	minTickLabelCells = 12.0
+)
+
+// RenderTrails draws the sampled paths of solar system bodies with a tick
+// at every sample and queues date labels for ticks that have room
+func RenderTrails(canvas *Canvas, labels *LabelLayout, trails []astro.Trail, centerAlt, centerAz, fov float64) {
+	basis := newViewBasis(centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+	maxStep := math.Min(2.0, fov/30.0) * math.Pi / 180.0
+
+	th := theme.Current()
+	labelStyle := lipgloss.NewStyle().Foreground(th.PlanetLabel).Faint(true)
+
+	for _, trail := range trails {
+		color := lipgloss.Color("245")
+		if style, ok := planetStyles[trail.Body]; ok {
+			color = style.color
+		}
+		lineStyle := lipgloss.NewStyle().Foreground(th.Color(color)).Faint(true)
+		tickStyle := lipgloss.NewStyle().Foreground(th.Color(color))
+
+		// Path between samples
+		for i := 1; i < len(trail.Points); i++ {
+			prev, cur := trail.Points[i-1], trail.Points[i]
+			drawArc(canvas, basis,
+				horizontalVector(prev.Altitude, prev.Azimuth),
+				horizontalVector(cur.Altitude, cur.Azimuth),
+				maxStep, lineStyle)
+		}
+
+		// Ticks and date labels, with the time of day for sub-day steps
+		format := "Jan 2"
+		if len(trail.Points) > 1 && trail.Points[1].Time.Sub(trail.Points[0].Time) < 24*time.Hour {
+			format = "Jan 2 15:04"
+		}
+		tickX, tickY := math.Inf(1), math.Inf(1)
+		labelX, labelY := math.Inf(1), math.Inf(1)
+		for _, p := range trail.Points {
+			x, y, visible := Project(p.Altitude, p.Azimuth, centerAlt, centerAz, fov, canvas.Width, canvas.Height)
+			if !visible {
+				continue
+			}
+
+			if screenDistance(x, y, tickX, tickY) < minTickCells {
+				continue
+			}
+			tickX, tickY = float64(x), float64(y)
+
+			cell := canvas.Cells[y][x]
+			if cell.Char == ' ' || cell.Background {
+				canvas.SetBackground(x, y, '+', tickStyle)
+			}
+
+			if screenDistance(x, y, labelX, labelY) < minTickLabelCells {
+				continue
+			}
+			labelX, labelY = float64(x), float64(y)
+
+			labels.Add(Label{
+				Text:     p.Time.Format(format),
+				X:        x,
+				Y:        y,
+				Priority: PriorityTrail,
+				Rank:     float64(p.Time.Unix()),
+				Style:    labelStyle,
+			})
+		}
+	}
+}
+
+// screenDistance returns the distance in column widths between a cell and
+// a point; rows are twice as tall as columns are wide
+func screenDistance(x, y int, px, py float64) float64 {
+	return math.Hypot(float64(x)-px, (float64(y)-py)*cellAspect)
+}
@@ -0,0 +1,44 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/craigderington/skyterm/internal/astro"
+)
+
+func TestRenderTrailsTicksAndDates(t *testing.T) {
+	canvas := NewCanvas(80, 20)
+	labels := NewLabelLayout(canvas)
+
+	// A path along the horizon, one sample per 5° of azimuth
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	trail := astro.Trail{Body: "Mars"}
+	for i := 0; i < 7; i++ {
+		trail.Points = append(trail.Points, astro.TrailPoint{
+			Time:     start.Add(time.Duration(i) * 24 * time.Hour),
+			Altitude: 0,
+			Azimuth:  165 + 5*float64(i),
+		})
+	}
+
+	RenderTrails(canvas, labels, []astro.Trail{trail}, 0, 180, 40)
+	labels.Draw()
+
+	row := rowText(canvas, 10)
+	if strings.Count(row, "+") != 7 {
+		t.Errorf("want 7 ticks, got %q", row)
+	}
+	if !strings.Contains(row, "─") {
+		t.Errorf("path between ticks not drawn: %q", row)
+	}
+
+	text := ""
+	for y := 0; y < canvas.Height; y++ {
+		text += rowText(canvas, y)
+	}
+	if !strings.Contains(text, "Mar 1") {
+		t.Error("first sample should carry a date label")
+	}
+}
@@ -0,0 +1,116 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/astro"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// planetDarkLimit is the limiting magnitude applied to planets under a dark
+// sky. Planets are drawn regardless of the user's star magnitude limit, so
+// only daylight, twilight and moonlight can hide them.
+const planetDarkLimit = 8.0
+
+// Visibility decides which objects are bright enough to be drawn
+type Visibility struct {
+	Limit    float64              // Faintest magnitude under a dark sky
+	Sky      *astro.SkyConditions // Nil renders an always-dark sky
+	Observer *astro.Observer      // Site for extinction and its horizon profile, nil disables both
+}
+
+// Obstructed reports whether a position lies behind the site's horizon
+// profile. Without a profile nothing is hidden, even below the horizon.
+func (v Visibility) Obstructed(alt, az float64) bool {
+	if v.Observer == nil || len(v.Observer.Horizon) == 0 {
+		return false
+	}
+	return alt < v.Observer.Horizon.AltitudeAt(az)
+}
+
+// ApparentMagnitude returns a catalog magnitude dimmed by atmospheric
+// extinction at the given altitude
+func (v Visibility) ApparentMagnitude(magnitude, alt float64) float64 {
+	if v.Observer == nil {
+		return magnitude
+	}
+	return magnitude + v.Observer.ExtinctionAt(alt)
+}
+
+// LimitAt returns the effective limiting magnitude at a position
+func (v Visibility) LimitAt(alt, az float64) float64 {
+	if v.Sky == nil {
+		return v.Limit
+	}
+	return v.Sky.LimitingMagnitude(v.Limit, alt, az)
+}
+
+// Visible reports whether an object of the given catalog magnitude shows at
+// a position once extinction is applied and the horizon profile is clear
+func (v Visibility) Visible(magnitude, alt, az float64) bool {
+	return !v.Obstructed(alt, az) && v.ApparentMagnitude(magnitude, alt) <= v.LimitAt(alt, az)
+}
+
+// DeepSkyVisible reports whether a deep sky object is bright enough to mark.
+// Objects are shown up to three magnitudes past the star limit since they
+// are usually observed with optical aid.
+func (v Visibility) DeepSkyVisible(magnitude, alt, az float64) bool {
+	return !v.Obstructed(alt, az) && v.ApparentMagnitude(magnitude, alt) <= v.LimitAt(alt, az)+3
+}
+
+// PlanetVisible reports whether a solar system body shows. Bodies behind
+// the horizon profile are hidden; otherwise the Sun and Moon are always
+// drawn and planets disappear in daylight and twilight.
+func (v Visibility) PlanetVisible(planet astro.Planet) bool {
+	if v.Obstructed(planet.Altitude, planet.Azimuth) {
+		return false
+	}
+	if planet.BodyType == astro.BodyTypeSun || planet.BodyType == astro.BodyTypeMoon {
+		return true
+	}
+	if v.Sky == nil {
+		return true
+	}
+	magnitude := v.ApparentMagnitude(planet.Magnitude, planet.Altitude)
+	return magnitude <= v.Sky.LimitingMagnitude(planetDarkLimit, planet.Altitude, planet.Azimuth)
+}
+
+// Sky colors at the key solar altitudes, blended in between
+var skyGradient = []struct {
+	sunAltitude float64
+	r, g, b     float64
+}{
+	{-18, 0, 0, 0},
+	{-12, 8, 10, 30},
+	{-6, 25, 35, 80},
+	{0, 70, 90, 150},
+	{10, 90, 140, 210},
+}
+
+// SkyColor returns the background color for the given sky conditions, or
+// NoColor once the sky is fully dark so the terminal background shows
+func SkyColor(sky *astro.SkyConditions) lipgloss.TerminalColor {
+	if sky == nil || sky.SunAltitude <= skyGradient[0].sunAltitude {
+		return lipgloss.NoColor{}
+	}
+
+	last := skyGradient[len(skyGradient)-1]
+	r, g, b := last.r, last.g, last.b
+	for i := 1; i < len(skyGradient); i++ {
+		lo, hi := skyGradient[i-1], skyGradient[i]
+		if sky.SunAltitude <= hi.sunAltitude {
+			f := (sky.SunAltitude - lo.sunAltitude) / (hi.sunAltitude - lo.sunAltitude)
+			r = lo.r + (hi.r-lo.r)*f
+			g = lo.g + (hi.g-lo.g)*f
+			b = lo.b + (hi.b-lo.b)*f
+			break
+		}
+	}
+
+	if r < 1 && g < 1 && b < 1 {
+		return lipgloss.NoColor{}
+	}
+
+	return theme.Current().Color(lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", uint8(r), uint8(g), uint8(b))))
+}
@@ -0,0 +1,51 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/craigderington/skyterm/internal/astro"
+)
+
+func TestVisibilityHorizonProfile(t *testing.T) {
+	observer := astro.NewObserver(40, -105, 0, "Test")
+	vis := Visibility{Limit: 6, Observer: observer}
+
+	// Without a profile nothing is hidden, not even below the horizon
+	if !vis.Visible(1, -5, 180) {
+		t.Error("star hidden with no horizon profile")
+	}
+
+	// Trees to the south block the lowest 20°
+	observer.Horizon = astro.NewHorizonProfile([]astro.HorizonPoint{
+		{Azimuth: 0, Altitude: 0},
+		{Azimuth: 180, Altitude: 20},
+	})
+	tests := []struct {
+		alt, az float64
+		want    bool
+	}{
+		{10, 180, false},
+		{25, 180, true},
+		{10, 90, true}, // The profile is 10° here
+		{5, 90, false},
+		{5, 0, true},
+	}
+	for _, tt := range tests {
+		if got := vis.Visible(1, tt.alt, tt.az); got != tt.want {
+			t.Errorf("star at alt %v az %v visible = %v, want %v", tt.alt, tt.az, got, tt.want)
+		}
+		if got := vis.DeepSkyVisible(8, tt.alt, tt.az); got != tt.want {
+			t.Errorf("deep sky at alt %v az %v visible = %v, want %v", tt.alt, tt.az, got, tt.want)
+		}
+	}
+
+	// The Sun and Moon are hidden behind the trees too
+	moon := astro.Planet{Name: "Moon", BodyType: astro.BodyTypeMoon, Altitude: 10, Azimuth: 180}
+	if vis.PlanetVisible(moon) {
+		t.Error("Moon behind the profile is visible")
+	}
+	moon.Altitude = 25
+	if !vis.PlanetVisible(moon) {
+		t.Error("Moon above the profile is hidden")
+	}
+}
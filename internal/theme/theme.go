@@ -0,0 +1,254 @@
+package theme
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Tint controls how object colors (stars, planets, deep sky) are adjusted
+type Tint int
+
+const (
+	TintNone Tint = iota // Keep natural colors
+	TintGray             // Convert to gray of equal brightness
+	TintRed              // Convert to red of equal brightness
+)
+
+// Theme holds every color used by the sky view and the UI
+type Theme struct {
+	Name string
+
+	// Sky view
+	Grid               lipgloss.Color
+	GridLabel          lipgloss.Color
+	Cardinal           lipgloss.Color
+	ConstellationLine  lipgloss.Color
+	ConstellationLabel lipgloss.Color
+	StarLabel          lipgloss.Color
+	PlanetLabel        lipgloss.Color
+	DeepSkyLabel       lipgloss.Color
+	Overlay            lipgloss.Color // Equipment field of view
+
+	// UI chrome
+	Border           lipgloss.Color
+	Title            lipgloss.Color
+	Heading          lipgloss.Color
+	Key              lipgloss.Color
+	Text             lipgloss.Color
+	Muted            lipgloss.Color
+	Warning          lipgloss.Color
+	Error            lipgloss.Color
+	StatusText       lipgloss.Color
+	StatusBackground lipgloss.Color
+	InputBackground  lipgloss.Color
+
+	// Object colors and images
+	Tint       Tint
+	ShowImages bool // False hides photos that would ruin dark adaptation
+}
+
+// Built-in themes
+var (
+	Default = Theme{
+		Name:               "default",
+		Grid:               "238",
+		GridLabel:          "242",
+		Cardinal:           "226",
+		ConstellationLine:  "240",
+		ConstellationLabel: "51",
+		StarLabel:          "250",
+		PlanetLabel:        "226",
+		DeepSkyLabel:       "176",
+		Overlay:            "214",
+		Border:             "51",
+		Title:              "51",
+		Heading:            "226",
+		Key:                "46",
+		Text:               "231",
+		Muted:              "240",
+		Warning:            "226",
+		Error:              "196",
+		StatusText:         "240",
+		StatusBackground:   "235",
+		InputBackground:    "236",
+		Tint:               TintNone,
+		ShowImages:         true,
+	}
+
+	HighContrast = Theme{
+		Name:               "high-contrast",
+		Grid:               "244",
+		GridLabel:          "250",
+		Cardinal:           "226",
+		ConstellationLine:  "248",
+		ConstellationLabel: "87",
+		StarLabel:          "231",
+		PlanetLabel:        "226",
+		DeepSkyLabel:       "213",
+		Overlay:            "214",
+		Border:             "231",
+		Title:              "231",
+		Heading:            "226",
+		Key:                "118",
+		Text:               "231",
+		Muted:              "250",
+		Warning:            "226",
+		Error:              "196",
+		StatusText:         "231",
+		StatusBackground:   "16",
+		InputBackground:    "238",
+		Tint:               TintNone,
+		ShowImages:         true,
+	}
+
+	Monochrome = Theme{
+		Name:               "monochrome",
+		Grid:               "237",
+		GridLabel:          "241",
+		Cardinal:           "252",
+		ConstellationLine:  "240",
+		ConstellationLabel: "250",
+		StarLabel:          "246",
+		PlanetLabel:        "252",
+		DeepSkyLabel:       "246",
+		Overlay:            "255",
+		Border:             "245",
+		Title:              "255",
+		Heading:            "252",
+		Key:                "250",
+		Text:               "252",
+		Muted:              "240",
+		Warning:            "255",
+		Error:              "255",
+		StatusText:         "245",
+		StatusBackground:   "234",
+		InputBackground:    "236",
+		Tint:               TintGray,
+		ShowImages:         true,
+	}
+
+	Night = Theme{
+		Name:               "night",
+		Grid:               "52",
+		GridLabel:          "52",
+		Cardinal:           "124",
+		ConstellationLine:  "52",
+		ConstellationLabel: "88",
+		StarLabel:          "88",
+		PlanetLabel:        "124",
+		DeepSkyLabel:       "88",
+		Overlay:            "124",
+		Border:             "88",
+		Title:              "124",
+		Heading:            "124",
+		Key:                "124",
+		Text:               "124",
+		Muted:              "52",
+		Warning:            "160",
+		Error:              "160",
+		StatusText:         "88",
+		StatusBackground:   "232",
+		InputBackground:    "52",
+		Tint:               TintRed,
+		ShowImages:         false,
+	}
+)
+
+// Builtin returns the built-in themes in cycling order
+func Builtin() []Theme {
+	return []Theme{Default, HighContrast, Monochrome, Night}
+}
+
+// ByName looks up a built-in theme. "red" is accepted as an alias for night.
+func ByName(name string) (Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return Default, nil
+	}
+	if name == "red" {
+		return Night, nil
+	}
+
+	for _, t := range Builtin() {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+
+	return Default, fmt.Errorf("unknown theme %q", name)
+}
+
+var current = Default
+
+// Current returns the active theme
+func Current() Theme {
+	return current
+}
+
+// Set makes t the active theme
+func Set(t Theme) {
+	current = t
+}
+
+// Next returns the built-in theme following t in cycling order
+func Next(t Theme) Theme {
+	themes := Builtin()
+	for i, candidate := range themes {
+		if candidate.Name == t.Name {
+			return themes[(i+1)%len(themes)]
+		}
+	}
+	return themes[0]
+}
+
+// Color adjusts an object color according to the theme's tint.
+// Colors may be ANSI indices ("196") or hex values ("#ff8800").
+func (t Theme) Color(c lipgloss.Color) lipgloss.Color {
+	if t.Tint == TintNone {
+		return c
+	}
+
+	rgb, ok := toRGB(c)
+	if !ok {
+		return c
+	}
+
+	// Perceived brightness (Rec. 601 luma)
+	luma := 0.299*rgb.R + 0.587*rgb.G + 0.114*rgb.B
+
+	switch t.Tint {
+	case TintGray:
+		v := uint8(luma * 255)
+		return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", v, v, v))
+	case TintRed:
+		// Keep faint objects visible without going above a dim red
+		v := uint8(60 + luma*170)
+		return lipgloss.Color(fmt.Sprintf("#%02x0000", v))
+	}
+
+	return c
+}
+
+// toRGB resolves a color to RGB components in the range 0-1
+func toRGB(c lipgloss.Color) (rgb struct{ R, G, B float64 }, ok bool) {
+	s := string(c)
+
+	var tc termenv.Color
+	if strings.HasPrefix(s, "#") {
+		tc = termenv.RGBColor(s)
+	} else {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 || n > 255 {
+			return rgb, false
+		}
+		tc = termenv.ANSI256Color(n)
+	}
+
+	col := termenv.ConvertToRGB(tc)
+	rgb.R, rgb.G, rgb.B = col.R, col.G, col.B
+	return rgb, true
+}
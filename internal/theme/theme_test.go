@@ -0,0 +1,57 @@
+package theme
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestNightTintIsRed(t *testing.T) {
+	for _, c := range []lipgloss.Color{"231", "27", "#a0c8ff", "46"} {
+		got := string(Night.Color(c))
+		if len(got) != 7 || got[3:] != "0000" {
+			t.Errorf("Night.Color(%q) = %q, want a pure red", c, got)
+		}
+	}
+}
+
+func TestNightTintKeepsBrightnessOrder(t *testing.T) {
+	bright, _ := strconv.ParseInt(string(Night.Color("231"))[1:3], 16, 0)
+	dim, _ := strconv.ParseInt(string(Night.Color("238"))[1:3], 16, 0)
+	if bright <= dim {
+		t.Errorf("white should map to a brighter red than dark gray (%d <= %d)", bright, dim)
+	}
+}
+
+func TestMonochromeTintIsGray(t *testing.T) {
+	got := string(Monochrome.Color("196"))
+	if got[1:3] != got[3:5] || got[3:5] != got[5:7] {
+		t.Errorf("Monochrome.Color(196) = %q, want a gray", got)
+	}
+}
+
+func TestDefaultKeepsColors(t *testing.T) {
+	if got := Default.Color("#123456"); got != "#123456" {
+		t.Errorf("default theme changed color to %q", got)
+	}
+}
+
+func TestByName(t *testing.T) {
+	if th, err := ByName("red"); err != nil || th.Name != "night" {
+		t.Errorf("ByName(red) = %q, %v", th.Name, err)
+	}
+	if _, err := ByName("sepia"); err == nil {
+		t.Error("expected error for unknown theme")
+	}
+}
+
+func TestNextCycles(t *testing.T) {
+	th := Default
+	for range Builtin() {
+		th = Next(th)
+	}
+	if th.Name != Default.Name {
+		t.Errorf("cycling through all themes ended at %q", th.Name)
+	}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// RenderCommandLine renders the ":" prompt in place of the status bar, with
+// any completion candidates after the cursor, or else the key hints
+func RenderCommandLine(input string, candidates []string, hints []HelpEntry, width int) string {
+	th := theme.Current()
+
+	lineStyle := lipgloss.NewStyle().
+		Foreground(th.StatusText).
+		Background(th.StatusBackground)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(th.Muted).
+		Background(th.StatusBackground)
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(th.Key).
+		Background(th.StatusBackground)
+
+	prompt := ":" + input + "█"
+	room := width - lipgloss.Width(prompt)
+
+	var hint string
+	switch {
+	case len(candidates) > 0:
+		// Keep to one line, cutting the candidates short
+		hint = "  " + strings.Join(candidates, " ")
+		if lipgloss.Width(hint) > room {
+			runes := []rune(hint)
+			if room > 1 {
+				hint = string(runes[:room-1]) + "…"
+			} else {
+				hint = ""
+			}
+		}
+		hint = hintStyle.Render(hint)
+	case len(hints) > 0:
+		// Key hints are dropped whole when they don't fit
+		parts := make([]string, len(hints))
+		for i, e := range hints {
+			parts[i] = keyStyle.Render(e.Keys) + hintStyle.Render(" "+e.Desc)
+		}
+		hint = hintStyle.Render("  ") + strings.Join(parts, hintStyle.Render(" • "))
+		if lipgloss.Width(hint) > room {
+			hint = ""
+		}
+	}
+
+	line := lineStyle.Render(prompt) + hint
+	if pad := width - lipgloss.Width(line); pad > 0 {
+		line += lineStyle.Render(strings.Repeat(" ", pad))
+	}
+	return line
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// RenderGotoInput renders the coordinate entry modal with the given key
+// hints. errMsg, when set, explains why the last entry was rejected.
+func RenderGotoInput(input, errMsg string, hints []HelpEntry, width, height int) string {
+	th := theme.Current()
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(th.Title).
+		Bold(true).
+		Padding(0, 1)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(th.Muted)
+
+	inputStyle := lipgloss.NewStyle().
+		Foreground(th.Text).
+		Bold(true)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(th.Error)
+
+	instructionStyle := lipgloss.NewStyle().
+		Foreground(th.Muted).
+		Faint(true)
+
+	// Build content
+	content := titleStyle.Render("Go To Coordinates") + "\n\n"
+	content += labelStyle.Render("Enter RA/Dec or Alt/Az:") + "\n"
+	content += inputStyle.Render(input+"█") + "\n"
+	if errMsg != "" {
+		content += errorStyle.Render(errMsg) + "\n"
+	}
+	content += "\n"
+	content += instructionStyle.Render(`RA/Dec: 05h35m17s -05°23'28"`) + "\n"
+	content += instructionStyle.Render("    or: 05:35:17 -05:23:28, 83.82 -5.39") + "\n"
+	content += instructionStyle.Render("Add jnow for equinox of date (J2000 default)") + "\n"
+	content += instructionStyle.Render("Alt/Az: altaz 30 225") + "\n\n"
+	content += RenderKeyHints(hints)
+
+	// Create modal with border
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(th.Border).
+		Padding(1, 2).
+		Width(50)
+
+	modal := modalStyle.Render(content)
+
+	// Center the modal
+	positioned := lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Center,
+		modal,
+		lipgloss.WithWhitespaceChars(" "),
+	)
+
+	return positioned
+}
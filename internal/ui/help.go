@@ -1,86 +1,188 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
-// RenderHelp returns a help screen with keybindings
-func RenderHelp(width, height int) string {
+// HelpEntry is one line of the help screen
+type HelpEntry struct {
+	Keys string
+	Desc string
+}
+
+// HelpSection is a titled group of help entries
+type HelpSection struct {
+	Title   string
+	Entries []HelpEntry
+}
+
+// HelpView is the state of the help screen
+type HelpView struct {
+	Sections  []HelpSection
+	Query     string      // Only matching entries are listed
+	Searching bool        // The query is being typed
+	Scroll    int         // First listed line shown
+	Hints     []HelpEntry // Keys of the help screen itself, for the footer
+}
+
+// helpChrome is the number of lines around the scrolling list: border,
+// title, filter line and footer
+const helpChrome = 7
+
+// helpWidth is the width of the help text block
+const helpWidth = 72
+
+// FilterHelp returns the entries matching query, ignoring case. A section
+// whose title matches is kept whole; sections left empty are dropped.
+func FilterHelp(sections []HelpSection, query string) []HelpSection {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return sections
+	}
+
+	var filtered []HelpSection
+	for _, section := range sections {
+		if strings.Contains(strings.ToLower(section.Title), q) {
+			filtered = append(filtered, section)
+			continue
+		}
+		var entries []HelpEntry
+		for _, e := range section.Entries {
+			if strings.Contains(strings.ToLower(e.Keys), q) || strings.Contains(strings.ToLower(e.Desc), q) {
+				entries = append(entries, e)
+			}
+		}
+		if len(entries) > 0 {
+			filtered = append(filtered, HelpSection{Title: section.Title, Entries: entries})
+		}
+	}
+	return filtered
+}
+
+// HelpPageHeight returns the number of listed lines visible at a screen height
+func HelpPageHeight(height int) int {
+	return max(1, height-helpChrome)
+}
+
+// helpLineCount is the number of lines listing the sections
+func helpLineCount(sections []HelpSection) int {
+	n := 0
+	for i, section := range sections {
+		if i > 0 {
+			n++ // Blank line between sections
+		}
+		n += 1 + len(section.Entries)
+	}
+	return n
+}
+
+// HelpMaxScroll returns the largest useful scroll offset for the view
+func HelpMaxScroll(view HelpView, height int) int {
+	lines := helpLineCount(FilterHelp(view.Sections, view.Query))
+	return max(0, lines-HelpPageHeight(height))
+}
+
+// RenderHelp returns a scrollable help screen listing the view's keybindings
+func RenderHelp(view HelpView, width, height int) string {
+	th := theme.Current()
+
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("cyan")).
+		Foreground(th.Title).
 		Bold(true)
 
 	sectionStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("yellow")).
+		Foreground(th.Heading).
 		Bold(true)
 
 	keyStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("green")).
-		Width(18)
+		Foreground(th.Key).
+		Width(20)
 
 	descStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(th.Muted).
 		Width(40)
 
-	// Helper function to create a help line with aligned columns
-	line := func(key, desc string) string {
-		return "  " + keyStyle.Render(key) + " " + descStyle.Render(desc)
+	inputStyle := lipgloss.NewStyle().
+		Foreground(th.Text).
+		Background(th.InputBackground)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(th.Muted)
+
+	// Every listed line, scrolled as one
+	sections := FilterHelp(view.Sections, view.Query)
+	var lines []string
+	for i, section := range sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, sectionStyle.Render(section.Title))
+		for _, e := range section.Entries {
+			lines = append(lines, "  "+keyStyle.Render(e.Keys)+" "+descStyle.Render(e.Desc))
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, mutedStyle.Render("No keys match"))
 	}
 
+	page := HelpPageHeight(height)
+	scroll := max(0, min(view.Scroll, len(lines)-page))
+	end := min(len(lines), scroll+page)
+
 	var help string
-	help += titleStyle.Render("skyterm - Terminal Astronomy") + "\n\n"
-
-	help += sectionStyle.Render("Navigation") + "\n"
-	help += line("↑/k, ↓/j", "Pan up/down") + "\n"
-	help += line("←/h, →/l", "Pan left/right") + "\n"
-	help += line("K/J/H/L", "Fast pan") + "\n"
-	help += line("+/-", "Zoom in/out") + "\n"
-	help += line("0", "Reset view") + "\n\n"
-
-	help += sectionStyle.Render("Cardinal Directions") + "\n"
-	help += line("n/s/e/w", "North/South/East/West") + "\n"
-	help += line("z", "Zenith (straight up)") + "\n\n"
-
-	help += sectionStyle.Render("Display Toggles") + "\n"
-	help += line("g", "Toggle coordinate grid") + "\n"
-	help += line("C", "Toggle constellation lines") + "\n"
-	help += line("N", "Toggle constellation names") + "\n"
-	help += line("p", "Toggle planets (Sun, Moon, planets)") + "\n"
-	help += line("P", "Toggle planet labels") + "\n"
-	help += line("d", "Toggle deep sky objects (Messier)") + "\n"
-	help += line("S", "Toggle star labels (bright stars)") + "\n"
-	help += line("m", "Cycle magnitude limit (3/4/5/6)") + "\n\n"
-
-	help += sectionStyle.Render("Object Interaction") + "\n"
-	help += line("Enter", "Select nearest object to center") + "\n"
-	help += line("i", "Toggle info panel for selected") + "\n"
-	help += line("c", "Center view on selected object") + "\n"
-	help += line("f", "Follow selected object (lock view)") + "\n"
-	help += line("/", "Search for object by name") + "\n\n"
-
-	help += sectionStyle.Render("Time Controls") + "\n"
-	help += line("Space", "Pause/resume time flow") + "\n"
-	help += line("[ / ]", "Step time backward/forward") + "\n"
-	help += line("{ / }", "Fast step time backward/forward") + "\n"
-	help += line("T", "Jump to current time (now)") + "\n"
-	help += line("t", "Set custom time") + "\n\n"
-
-	help += sectionStyle.Render("General") + "\n"
-	help += line("?", "Toggle this help screen") + "\n"
-	help += line("q, Ctrl+C", "Quit application") + "\n\n\n"
-
-	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
-		Faint(true)
-	help += footerStyle.Render("Stars update in real-time based on your location and current time.")
-
-	// Center the help text
+	help += titleStyle.Render("skyterm - Terminal Astronomy")
+	if len(lines) > page {
+		help += mutedStyle.Render(fmt.Sprintf("  (%d-%d of %d)", scroll+1, end, len(lines)))
+	}
+	help += "\n\n"
+
+	switch {
+	case view.Searching:
+		help += mutedStyle.Render("Filter: ") + inputStyle.Render(view.Query+"█")
+	case view.Query != "":
+		help += mutedStyle.Render("Filter: ") + view.Query
+	}
+	help += "\n"
+
+	help += strings.Join(lines[scroll:end], "\n")
+	help += strings.Repeat("\n", page-(end-scroll))
+	help += "\n\n"
+
+	help += lipgloss.NewStyle().MaxWidth(helpWidth).Render(RenderKeyHints(view.Hints))
+
+	// Pad every line to the block width so the columns stay aligned when
+	// the block is centered
+	block := lipgloss.NewStyle().Width(helpWidth).Render(help)
+
+	// The border takes a line and a column on each side
 	style := lipgloss.NewStyle().
-		Width(width).
-		Height(height).
+		Width(width-2).
+		Height(height-2).
 		Align(lipgloss.Center, lipgloss.Center).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("cyan"))
+		BorderForeground(th.Border)
+
+	return style.Render(block)
+}
 
-	return style.Render(help)
+// RenderKeyHints renders bindings as a one-line hint, e.g. "enter Set time •
+// esc Cancel", for the footer of a mode
+func RenderKeyHints(entries []HelpEntry) string {
+	th := theme.Current()
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(th.Key)
+
+	descStyle := lipgloss.NewStyle().
+		Foreground(th.Muted)
+
+	hints := make([]string, len(entries))
+	for i, e := range entries {
+		hints[i] = keyStyle.Render(e.Keys) + " " + descStyle.Render(e.Desc)
+	}
+	return strings.Join(hints, descStyle.Render(" • "))
 }
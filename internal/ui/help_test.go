@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+var testSections = []HelpSection{
+	{Title: "Navigation", Entries: []HelpEntry{
+		{Keys: "↑/k", Desc: "Pan up"},
+		{Keys: "+", Desc: "Zoom in"},
+		{Keys: "-", Desc: "Zoom out"},
+	}},
+	{Title: "Time Controls", Entries: []HelpEntry{
+		{Keys: "space", Desc: "Pause/resume time flow"},
+		{Keys: "T", Desc: "Jump to current time (now)"},
+	}},
+}
+
+func TestFilterHelp(t *testing.T) {
+	filtered := FilterHelp(testSections, "ZOOM")
+	if len(filtered) != 1 || len(filtered[0].Entries) != 2 {
+		t.Fatalf("want the two zoom entries, got %+v", filtered)
+	}
+
+	// A matching title keeps its whole section
+	filtered = FilterHelp(testSections, "time controls")
+	if len(filtered) != 1 || len(filtered[0].Entries) != 2 {
+		t.Errorf("want the whole Time Controls section, got %+v", filtered)
+	}
+
+	// Keys match too
+	if filtered = FilterHelp(testSections, "space"); len(filtered) != 1 || filtered[0].Entries[0].Desc != "Pause/resume time flow" {
+		t.Errorf("want the space binding, got %+v", filtered)
+	}
+
+	if filtered = FilterHelp(testSections, "nothing"); len(filtered) != 0 {
+		t.Errorf("want no sections, got %+v", filtered)
+	}
+	if filtered = FilterHelp(testSections, " "); len(filtered) != len(testSections) {
+		t.Errorf("blank query should keep every section")
+	}
+}
+
+func TestHelpMaxScroll(t *testing.T) {
+	// Two headings, five entries and a blank line between sections
+	lines := 8
+
+	view := HelpView{Sections: testSections}
+	if got := HelpMaxScroll(view, 100); got != 0 {
+		t.Errorf("tall screen: max scroll = %d, want 0", got)
+	}
+
+	height := helpChrome + 3
+	if got := HelpMaxScroll(view, height); got != lines-3 {
+		t.Errorf("three-line page: max scroll = %d, want %d", got, lines-3)
+	}
+
+	view.Query = "zoom"
+	if got := HelpMaxScroll(view, height); got != 0 {
+		t.Errorf("filtered: max scroll = %d, want 0", got)
+	}
+}
+
+func TestRenderHelpFitsScreen(t *testing.T) {
+	view := HelpView{Sections: testSections, Scroll: 2}
+	out := RenderHelp(view, 80, 12)
+
+	if got := strings.Count(out, "\n") + 1; got != 12 {
+		t.Errorf("rendered %d lines, want 12", got)
+	}
+	if strings.Contains(out, "Pan up") {
+		t.Errorf("scrolled past the first entries but still shows them")
+	}
+	if !strings.Contains(out, "Zoom out") {
+		t.Errorf("missing an entry within the page")
+	}
+}
@@ -5,19 +5,23 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
-// RenderImageViewer renders a fullscreen image viewer
-func RenderImageViewer(objectInfo *ObjectInfo, width, height int) string {
+// RenderImageViewer renders a fullscreen image viewer with the keys that
+// close it in the footer
+func RenderImageViewer(objectInfo *ObjectInfo, hints []HelpEntry, width, height int) string {
+	th := theme.Current()
+
 	if objectInfo == nil || objectInfo.ImageData == "" {
 		return ""
 	}
 
 	// Create header with object name
 	headerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("cyan")).
+		Foreground(th.Title).
 		Bold(true).
-		Background(lipgloss.Color("235")).
+		Background(th.StatusBackground).
 		Width(width).
 		Align(lipgloss.Center).
 		Padding(0, 1)
@@ -28,7 +32,7 @@ func RenderImageViewer(objectInfo *ObjectInfo, width, height int) string {
 	var description string
 	if objectInfo.ImageInfo != nil && objectInfo.ImageInfo.Description != "" {
 		descStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("250")).
+			Foreground(th.Text).
 			Width(width - 4).
 			Align(lipgloss.Center).
 			Padding(0, 2)
@@ -37,13 +41,13 @@ func RenderImageViewer(objectInfo *ObjectInfo, width, height int) string {
 
 	// Create footer with instructions
 	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
-		Background(lipgloss.Color("235")).
+		Foreground(th.Muted).
+		Background(th.StatusBackground).
 		Width(width).
 		Align(lipgloss.Center).
 		Padding(0, 1)
 
-	footer := footerStyle.Render("Press ESC, V, or Q to close")
+	footer := footerStyle.Render(RenderKeyHints(hints))
 
 	// Calculate center position for image
 	// Split image into lines to count height
@@ -109,7 +113,7 @@ func RenderImageViewer(objectInfo *ObjectInfo, width, height int) string {
 	// Add Wikipedia source info if available
 	if objectInfo.ImageInfo != nil {
 		infoStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")).
+			Foreground(th.Muted).
 			Faint(true).
 			Width(width).
 			Align(lipgloss.Center)
@@ -2,11 +2,13 @@ package ui
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/craigderington/skyterm/internal/astro"
 	"github.com/craigderington/skyterm/internal/catalog"
 	"github.com/craigderington/skyterm/internal/image"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
 // ObjectInfo holds information about a selected object for display
@@ -16,53 +18,56 @@ type ObjectInfo struct {
 	Star         *catalog.Star
 	Planet       *astro.Planet
 	DeepSky      *catalog.MessierObject
+	Marker       *catalog.Marker
 	ImageInfo    *image.WikipediaImageInfo
 	ImageData    string // Rendered image for terminal
 	ImageLoading bool   // True while fetching image
 	ImageError   error  // Error if image fetch failed
 }
 
-// RenderInfoPanel renders an information panel for the selected object
-func RenderInfoPanel(info *ObjectInfo, observer *astro.Observer, width, height int) string {
+// RenderInfoPanel renders an information panel for the selected object.
+// Rise, transit and set times are for the day of now, in its zone.
+func RenderInfoPanel(info *ObjectInfo, observer *astro.Observer, now time.Time, width, height int) string {
 	if info == nil {
 		return ""
 	}
 
 	selected := info
+	th := theme.Current()
 
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("cyan")).
+		Foreground(th.Title).
 		Bold(true).
 		Padding(0, 1)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("green")).
+		Foreground(th.Key).
 		Width(15)
 
 	valueStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("231"))
+		Foreground(th.Text)
 
 	var content string
 
 	// Image status indicator
 	if selected.ImageLoading {
 		loadingStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("yellow")).
+			Foreground(th.Warning).
 			Italic(true)
 		content += loadingStyle.Render("Loading image...") + "\n\n"
-	} else if selected.ImageInfo != nil && selected.ImageData != "" {
+	} else if selected.ImageInfo != nil && selected.ImageData != "" && th.ShowImages {
 		// Show that an image is available with viewing instruction
 		infoStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("cyan")).
+			Foreground(th.Title).
 			Bold(true)
 		viewStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("green"))
+			Foreground(th.Key)
 		content += infoStyle.Render("📷 Image available") + "\n"
 		content += viewStyle.Render("Press 'v' to view fullscreen") + "\n\n"
 	} else if selected.ImageError != nil {
 		// Show error message
 		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("red")).
+			Foreground(th.Error).
 			Faint(true)
 		content += errorStyle.Render("(Image unavailable)") + "\n\n"
 	}
@@ -83,10 +88,11 @@ func RenderInfoPanel(info *ObjectInfo, observer *astro.Observer, width, height i
 		content += labelStyle.Render("Dec:") + valueStyle.Render(astro.FormatDec(s.Dec)) + "\n"
 		content += labelStyle.Render("Altitude:") + valueStyle.Render(fmt.Sprintf("%.1f°", s.Altitude)) + "\n"
 		content += labelStyle.Render("Azimuth:") + valueStyle.Render(fmt.Sprintf("%.1f°", s.Azimuth)) + "\n"
+		content += renderExtinction(s.Magnitude, s.Altitude, observer, labelStyle, valueStyle)
 		content += "\n"
 
 		// Calculate rise/set/transit times
-		rst := astro.CalculateRiseSetTransit(s.RA, s.Dec, observer, astro.CurrentTime())
+		rst := astro.CalculateRiseSetTransit(s.RA, s.Dec, observer, now)
 		if rst.NeverRises {
 			content += labelStyle.Render("Visibility:") + valueStyle.Render("Never rises") + "\n"
 		} else if rst.Circumpolar {
@@ -112,6 +118,7 @@ func RenderInfoPanel(info *ObjectInfo, observer *astro.Observer, width, height i
 		content += labelStyle.Render("Dec:") + valueStyle.Render(astro.FormatDec(p.Dec)) + "\n"
 		content += labelStyle.Render("Altitude:") + valueStyle.Render(fmt.Sprintf("%.1f°", p.Altitude)) + "\n"
 		content += labelStyle.Render("Azimuth:") + valueStyle.Render(fmt.Sprintf("%.1f°", p.Azimuth)) + "\n"
+		content += renderExtinction(p.Magnitude, p.Altitude, observer, labelStyle, valueStyle)
 
 	case "deepsky":
 		if selected.DeepSky == nil {
@@ -131,11 +138,47 @@ func RenderInfoPanel(info *ObjectInfo, observer *astro.Observer, width, height i
 		content += labelStyle.Render("Dec:") + valueStyle.Render(astro.FormatDec(d.Dec)) + "\n"
 		content += labelStyle.Render("Altitude:") + valueStyle.Render(fmt.Sprintf("%.1f°", d.Altitude)) + "\n"
 		content += labelStyle.Render("Azimuth:") + valueStyle.Render(fmt.Sprintf("%.1f°", d.Azimuth)) + "\n"
+		content += renderExtinction(d.Magnitude, d.Altitude, observer, labelStyle, valueStyle)
+
+	case "marker":
+		if selected.Marker == nil {
+			return ""
+		}
+		mk := selected.Marker
+
+		content += titleStyle.Render(mk.Name) + "\n"
+		content += valueStyle.Render(astro.FormatCoordinates(mk.Entered)) + "\n"
+		content += "\n"
+		content += labelStyle.Render("RA (J2000):") + valueStyle.Render(astro.FormatRA(mk.RA)) + "\n"
+		content += labelStyle.Render("Dec (J2000):") + valueStyle.Render(astro.FormatDec(mk.Dec)) + "\n"
+		content += labelStyle.Render("Altitude:") + valueStyle.Render(fmt.Sprintf("%.1f°", mk.Altitude)) + "\n"
+		content += labelStyle.Render("Azimuth:") + valueStyle.Render(fmt.Sprintf("%.1f°", mk.Azimuth)) + "\n"
+		if mk.Altitude > 0 {
+			content += labelStyle.Render("Airmass:") + valueStyle.Render(fmt.Sprintf("%.2f", astro.Airmass(mk.Altitude))) + "\n"
+		} else {
+			content += labelStyle.Render("Airmass:") + valueStyle.Render("below horizon") + "\n"
+		}
+
+		// Fixed Alt/Az markers don't rise or set
+		if mk.Entered.Frame != astro.FrameHorizontal {
+			content += "\n"
+			rst := astro.CalculateRiseSetTransit(mk.RA, mk.Dec, observer, now)
+			if rst.NeverRises {
+				content += labelStyle.Render("Visibility:") + valueStyle.Render("Never rises") + "\n"
+			} else if rst.Circumpolar {
+				content += labelStyle.Render("Visibility:") + valueStyle.Render("Circumpolar") + "\n"
+				content += labelStyle.Render("Transit:") + valueStyle.Render(rst.Transit.Format("15:04")) + "\n"
+			} else {
+				content += labelStyle.Render("Rises:") + valueStyle.Render(astro.FormatTime(rst.Rise)) + "\n"
+				content += labelStyle.Render("Transit:") + valueStyle.Render(rst.Transit.Format("15:04")) + "\n"
+				content += labelStyle.Render("Sets:") + valueStyle.Render(astro.FormatTime(rst.Set)) + "\n"
+			}
+		}
 	}
 
 	// Add close instruction
 	closeStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(th.Muted).
 		Faint(true)
 	content += "\n" + closeStyle.Render("Press 'i' again to close")
 
@@ -144,7 +187,7 @@ func RenderInfoPanel(info *ObjectInfo, observer *astro.Observer, width, height i
 
 	panelStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("51")).
+		BorderForeground(th.Border).
 		Padding(1, 2).
 		Width(panelWidth)
 
@@ -162,3 +205,15 @@ func RenderInfoPanel(info *ObjectInfo, observer *astro.Observer, width, height i
 
 	return positioned
 }
+
+// renderExtinction returns the airmass and extinguished magnitude lines for
+// an object, or a below-horizon note
+func renderExtinction(magnitude, altitude float64, observer *astro.Observer, labelStyle, valueStyle lipgloss.Style) string {
+	if altitude <= 0 {
+		return labelStyle.Render("Airmass:") + valueStyle.Render("below horizon") + "\n"
+	}
+
+	content := labelStyle.Render("Airmass:") + valueStyle.Render(fmt.Sprintf("%.2f", astro.Airmass(altitude))) + "\n"
+	content += labelStyle.Render("Extinct. Mag:") + valueStyle.Render(fmt.Sprintf("%.2f", magnitude+observer.ExtinctionAt(altitude))) + "\n"
+	return content
+}
@@ -1,37 +1,216 @@
 package ui
 
 import (
+	"fmt"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
-// RenderSearchBox renders a search input box
-func RenderSearchBox(query string, width, height int) string {
+// SearchItem is an object offered to the search engine
+type SearchItem struct {
+	Type      string   // "star", "planet", "deepsky"
+	Name      string   // Name used to select the object
+	Aliases   []string // Common names, NGC numbers and other designations
+	Magnitude float64
+	Altitude  float64
+}
+
+// SearchResult is an item that matched a query
+type SearchResult struct {
+	SearchItem
+	Score int
+	Match string // Alias that matched, empty when the name did
+}
+
+// Match quality, best first. A match on an alias scores one point less than
+// the same match on the name.
+const (
+	scoreExact     = 100
+	scorePrefix    = 80
+	scoreWord      = 60
+	scoreSubstring = 40
+	scoreFuzzy     = 20
+)
+
+// Search ranks items against a query and returns at most limit results.
+// Names and aliases are compared ignoring case, spaces and punctuation, so
+// "m 31", "M31" and "ngc224" all find the Andromeda Galaxy. Equal scores
+// favor objects above the horizon, then brighter ones.
+func Search(query string, items []SearchItem, limit int) []SearchResult {
+	q := compact(query)
+	if q == "" {
+		return nil
+	}
+
+	var results []SearchResult
+	for _, item := range items {
+		best := SearchResult{SearchItem: item, Score: matchScore(q, item.Name)}
+		for _, alias := range item.Aliases {
+			if score := matchScore(q, alias) - 1; score > best.Score {
+				best.Score = score
+				best.Match = alias
+			}
+		}
+		if best.Score > 0 {
+			results = append(results, best)
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		if a.Score != b.Score {
+			return b.Score - a.Score
+		}
+		if up := a.Altitude >= 0; up != (b.Altitude >= 0) {
+			if up {
+				return -1
+			}
+			return 1
+		}
+		switch {
+		case a.Magnitude < b.Magnitude:
+			return -1
+		case a.Magnitude > b.Magnitude:
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// matchScore rates how well a compacted query matches a name, 0 for no match
+func matchScore(q, name string) int {
+	c := compact(name)
+	switch {
+	case c == "":
+		return 0
+	case c == q:
+		return scoreExact
+	case strings.HasPrefix(c, q):
+		return scorePrefix
+	}
+
+	// Query starts at a later word, e.g. "galaxy" in "Andromeda Galaxy"
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i := 1; i < len(words); i++ {
+		if strings.HasPrefix(strings.Join(words[i:], ""), q) {
+			return scoreWord
+		}
+	}
+
+	if strings.Contains(c, q) {
+		return scoreSubstring
+	}
+
+	// Letters in order with gaps, losing a point per skipped letter
+	gaps, pos := 0, 0
+	for _, r := range q {
+		i := strings.IndexRune(c[pos:], r)
+		if i < 0 {
+			return 0
+		}
+		if pos > 0 {
+			gaps += i
+		}
+		pos += i + len(string(r))
+	}
+	return max(1, scoreFuzzy-gaps)
+}
+
+// compact lowercases s and drops everything but letters and digits
+func compact(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// searchTypeNames are the labels shown for each object type
+var searchTypeNames = map[string]string{
+	"star":    "Star",
+	"planet":  "Planet",
+	"deepsky": "Deep sky",
+}
+
+// RenderSearchBox renders the search input with the ranked results below it
+// and the given key hints. The result at index selected is highlighted.
+func RenderSearchBox(query string, results []SearchResult, selected int, hints []HelpEntry, width, height int) string {
+	th := theme.Current()
+
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("cyan")).
+		Foreground(th.Title).
 		Bold(true)
 
 	promptStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("green"))
+		Foreground(th.Key)
 
 	inputStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("231")).
-		Background(lipgloss.Color("236"))
+		Foreground(th.Text).
+		Background(th.InputBackground)
+
+	resultStyle := lipgloss.NewStyle().
+		Foreground(th.Text)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(th.Key).
+		Bold(true)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(th.Muted)
 
 	var content string
 	content += titleStyle.Render("Search Objects") + "\n\n"
 	content += promptStyle.Render("Enter object name: ")
-	content += inputStyle.Render(query + "█") + "\n\n"
-	content += lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
-		Render("Press Enter to search, Esc to cancel")
+	content += inputStyle.Render(query+"█") + "\n\n"
+
+	switch {
+	case query == "":
+		content += mutedStyle.Render("Names, Messier or NGC numbers") + "\n\n"
+	case len(results) == 0:
+		content += mutedStyle.Render("No matches") + "\n\n"
+	default:
+		content += mutedStyle.Render(fmt.Sprintf("  %-24s %-8s %5s %6s", "Name", "Type", "Mag", "Alt")) + "\n"
+		for i, r := range results {
+			name := r.Name
+			if r.Match != "" {
+				name += " (" + r.Match + ")"
+			}
+			if len([]rune(name)) > 24 {
+				name = string([]rune(name)[:23]) + "…"
+			}
+
+			line := fmt.Sprintf("%-24s %-8s %5.1f %5.0f°", name, searchTypeNames[r.Type], r.Magnitude, r.Altitude)
+			switch {
+			case i == selected:
+				content += selectedStyle.Render("› "+line) + "\n"
+			case r.Altitude < 0:
+				content += mutedStyle.Render("  "+line) + "\n"
+			default:
+				content += resultStyle.Render("  "+line) + "\n"
+			}
+		}
+		content += "\n"
+	}
+
+	content += RenderKeyHints(hints)
 
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("cyan")).
+		BorderForeground(th.Border).
 		Padding(1, 2).
-		Width(50)
+		Width(56)
 
 	box := boxStyle.Render(content)
 
@@ -46,35 +225,3 @@ func RenderSearchBox(query string, width, height int) string {
 
 	return centered
 }
-
-// SearchObjects performs a search across all object types
-func SearchObjects(query string, stars []string, planets []string, deepsky []string) (objectType, name string, found bool) {
-	query = strings.ToLower(strings.TrimSpace(query))
-
-	if query == "" {
-		return "", "", false
-	}
-
-	// Search stars
-	for _, star := range stars {
-		if strings.Contains(strings.ToLower(star), query) {
-			return "star", star, true
-		}
-	}
-
-	// Search planets
-	for _, planet := range planets {
-		if strings.Contains(strings.ToLower(planet), query) {
-			return "planet", planet, true
-		}
-	}
-
-	// Search deep sky
-	for _, obj := range deepsky {
-		if strings.Contains(strings.ToLower(obj), query) {
-			return "deepsky", obj, true
-		}
-	}
-
-	return "", "", false
-}
@@ -0,0 +1,64 @@
+package ui
+
+import "testing"
+
+var testItems = []SearchItem{
+	{Type: "star", Name: "Marsic", Magnitude: 5.2, Altitude: 40},
+	{Type: "planet", Name: "Mars", Magnitude: 1.0, Altitude: 20},
+	{Type: "star", Name: "Markab", Magnitude: 2.5, Altitude: -10},
+	{Type: "star", Name: "Mirach", Magnitude: 2.1, Altitude: 30},
+	{Type: "deepsky", Name: "M31", Aliases: []string{"Andromeda Galaxy", "NGC 224"}, Magnitude: 3.4, Altitude: 50},
+	{Type: "deepsky", Name: "M3", Magnitude: 6.2, Altitude: 60},
+}
+
+func TestSearchExactNameFirst(t *testing.T) {
+	results := Search("mars", testItems, 10)
+	if len(results) == 0 || results[0].Name != "Mars" {
+		t.Fatalf("Mars should rank first, got %+v", results)
+	}
+	if len(results) < 2 || results[1].Name != "Marsic" {
+		t.Errorf("Marsic should follow as a prefix match, got %+v", results)
+	}
+}
+
+func TestSearchAliases(t *testing.T) {
+	for _, query := range []string{"M 31", "m31", "ngc224", "NGC 224", "andromeda", "galaxy"} {
+		results := Search(query, testItems, 10)
+		if len(results) == 0 || results[0].Name != "M31" {
+			t.Errorf("%q: want M31 first, got %+v", query, results)
+		}
+	}
+
+	results := Search("ngc 224", testItems, 10)
+	if results[0].Match != "NGC 224" {
+		t.Errorf("match = %q, want the alias", results[0].Match)
+	}
+	if results = Search("m31", testItems, 10); results[0].Match != "" {
+		t.Errorf("name match reported alias %q", results[0].Match)
+	}
+}
+
+func TestSearchTiesFavorVisibleThenBright(t *testing.T) {
+	// "m" prefixes every name; objects below the horizon sort last
+	results := Search("m", testItems, 10)
+	if results[len(results)-1].Name != "Markab" {
+		t.Errorf("below-horizon object should sort last, got %+v", results)
+	}
+	if results[0].Name != "Mars" {
+		t.Errorf("brightest visible object should sort first, got %+v", results)
+	}
+}
+
+func TestSearchFuzzyAndLimit(t *testing.T) {
+	results := Search("mrch", testItems, 10)
+	if len(results) == 0 || results[0].Name != "Mirach" {
+		t.Errorf("fuzzy match failed: %+v", results)
+	}
+
+	if results := Search("m", testItems, 2); len(results) != 2 {
+		t.Errorf("limit not applied: %d results", len(results))
+	}
+	if results := Search("  ", testItems, 10); results != nil {
+		t.Errorf("blank query returned %+v", results)
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/theme"
+)
+
+// SiteChoice is one entry of the site picker
+type SiteChoice struct {
+	Name    string
+	Detail  string // Coordinates and other settings of the site
+	Current bool   // The site being observed from
+}
+
+// RenderSitePicker renders the site picker modal: a filter line and the
+// matching sites, with a manual entry first when the input is coordinates
+func RenderSitePicker(input string, choices []SiteChoice, selected int, errMsg string, hints []HelpEntry, width, height int) string {
+	th := theme.Current()
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(th.Title).
+		Bold(true)
+
+	promptStyle := lipgloss.NewStyle().
+		Foreground(th.Key)
+
+	inputStyle := lipgloss.NewStyle().
+		Foreground(th.Text).
+		Background(th.InputBackground)
+
+	choiceStyle := lipgloss.NewStyle().
+		Foreground(th.Text)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(th.Key).
+		Bold(true)
+
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(th.Muted)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(th.Error)
+
+	var content string
+	content += titleStyle.Render("Observing Site") + "\n\n"
+	content += promptStyle.Render("Site name or lat lon [elevation]: ")
+	content += inputStyle.Render(input+"█") + "\n"
+	if errMsg != "" {
+		content += errorStyle.Render(errMsg) + "\n"
+	}
+	content += "\n"
+
+	if len(choices) == 0 {
+		content += mutedStyle.Render("No matching sites") + "\n\n"
+	} else {
+		for i, c := range choices {
+			name := c.Name
+			if c.Current {
+				name += " *"
+			}
+			line := fmt.Sprintf("%-20s %s", truncate(name, 20), truncate(c.Detail, 49))
+			if i == selected {
+				content += selectedStyle.Render("› "+line) + "\n"
+			} else {
+				content += choiceStyle.Render("  "+line) + "\n"
+			}
+		}
+		content += "\n"
+	}
+
+	content += RenderKeyHints(hints)
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(th.Border).
+		Padding(1, 2).
+		Width(76)
+
+	box := boxStyle.Render(content)
+
+	// Center the picker
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Center,
+		box,
+	)
+}
+
+// truncate shortens s to n runes, ending in an ellipsis when cut
+func truncate(s string, n int) string {
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
@@ -2,41 +2,55 @@ package ui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/craigderington/skyterm/internal/theme"
 )
 
-// RenderTimeInput renders the time input modal
-func RenderTimeInput(input string, width, height int) string {
+// RenderTimeInput renders the time input modal with the given key hints.
+// errMsg, when set, explains why the last entry was rejected.
+func RenderTimeInput(input, errMsg string, hints []HelpEntry, width, height int) string {
+	th := theme.Current()
+
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("cyan")).
+		Foreground(th.Title).
 		Bold(true).
 		Padding(0, 1)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240"))
+		Foreground(th.Muted)
 
 	inputStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("231")).
+		Foreground(th.Text).
 		Bold(true)
 
+	errorStyle := lipgloss.NewStyle().
+		Foreground(th.Error)
+
 	instructionStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(th.Muted).
 		Faint(true)
 
 	// Build content
 	content := titleStyle.Render("Set Time") + "\n\n"
 	content += labelStyle.Render("Enter time:") + "\n"
-	content += inputStyle.Render(input + "█") + "\n\n"
-	content += instructionStyle.Render("Format: YYYY-MM-DD HH:MM:SS") + "\n"
-	content += instructionStyle.Render("    or: YYYY-MM-DD HH:MM") + "\n"
-	content += instructionStyle.Render("    or: YYYY-MM-DD") + "\n\n"
-	content += instructionStyle.Render("Press Enter to confirm, Esc to cancel")
+	content += inputStyle.Render(input + "█") + "\n"
+	if errMsg != "" {
+		content += errorStyle.Render(errMsg) + "\n"
+	}
+	content += "\n"
+	content += instructionStyle.Render("Date:   2026-08-12 22:00[:00] [UTC|America/Denver]") + "\n"
+	content += instructionStyle.Render("    or: 21:30, tomorrow 21:30, now") + "\n"
+	content += instructionStyle.Render("Offset: +2h, -3d, +1d6h") + "\n"
+	content += instructionStyle.Render("Julian: JD 2461000.5, MJD 61000") + "\n"
+	content += instructionStyle.Render("Event:  sunset, astro dusk, moonrise,") + "\n"
+	content += instructionStyle.Render("        next full moon, previous new moon") + "\n\n"
+	content += RenderKeyHints(hints)
 
 	// Create modal with border
 	modalStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("51")).
+		BorderForeground(th.Border).
 		Padding(1, 2).
-		Width(50)
+		Width(60)
 
 	modal := modalStyle.Render(content)
 